@@ -4,13 +4,63 @@
 
 import os
 import sys
-from typing import List, NoReturn, Optional
+from typing import Callable, List, NoReturn, Optional
 
 from kitty.cli import parse_args
 from kitty.cli_stub import ClipboardCLIOptions
 
 from ..tui.handler import Handler
 from ..tui.loop import Loop
+from ..tui.operations import (
+    CLIPBOARD_CHUNK_SIZE, request_from_clipboard, write_to_clipboard
+)
+
+
+def num_of_chunks(data: Optional[bytes]) -> int:
+    return (len(data or b'') + CLIPBOARD_CHUNK_SIZE - 1) // CLIPBOARD_CHUNK_SIZE
+
+
+def truncated(data: bytes, limit: int) -> bytes:
+    try:
+        data.decode('utf-8')
+    except UnicodeDecodeError:
+        return data[:limit]
+    # avoid leaving a partial UTF-8 sequence at the end
+    return data[:limit].decode('utf-8', 'ignore').encode('utf-8')
+
+
+def limited(
+    data: bytes, max_size: int, on_oversize: str = 'error',
+    warn: Optional[Callable[[str], None]] = None
+) -> bytes:
+    if max_size < 0:
+        raise SystemExit('The value of --max-size must not be negative')
+    if max_size and len(data) > max_size:
+        msg = 'The data to send is {} bytes ({} chunks) which is larger than the limit of {} bytes'.format(
+            len(data), num_of_chunks(data), max_size)
+        if on_oversize == 'error':
+            raise SystemExit(msg)
+        data = truncated(data, max_size)
+        msg = 'Warning: {}, truncating to {} bytes ({} chunks)'.format(msg, len(data), num_of_chunks(data))
+        if warn is None:
+            print(msg, file=sys.stderr)
+        else:
+            warn(msg)
+    return data
+
+
+def tmux_passthrough(escape_codes: str) -> str:
+    # wrap each escape code in its own tmux passthrough DCS, with any ESC
+    # bytes in it doubled, as tmux requires
+    return ''.join(
+        '\x1bPtmux;{}\x07\x1b\\'.format(x.replace('\x1b', '\x1b\x1b'))
+        for x in escape_codes.split('\x07') if x)
+
+
+def use_tmux_passthrough(passthrough: str) -> bool:
+    if passthrough == 'detect':
+        return bool(os.environ.get('TMUX'))
+    return passthrough == 'tmux'
 
 
 class Clipboard(Handler):
@@ -18,23 +68,58 @@ class Clipboard(Handler):
     def __init__(self, data_to_send: Optional[bytes], args: ClipboardCLIOptions):
         self.args = args
         self.clipboard_contents: Optional[str] = None
+        self.error_message = ''
         self.data_to_send = data_to_send
+        self.tmux_passthrough = use_tmux_passthrough(args.passthrough)
+
+    def write_to_clipboard(self, data: bytes) -> None:
+        if self.tmux_passthrough:
+            self.write(tmux_passthrough(write_to_clipboard(data, self.args.use_primary)))
+        else:
+            self.cmd.write_to_clipboard(data, self.args.use_primary)
+
+    def request_from_clipboard(self) -> None:
+        if self.tmux_passthrough:
+            self.write(tmux_passthrough(request_from_clipboard(self.args.use_primary)))
+        else:
+            self.cmd.request_from_clipboard(self.args.use_primary)
 
     def initialize(self) -> None:
         if self.data_to_send is not None:
-            self.cmd.write_to_clipboard(self.data_to_send, self.args.use_primary)
+            if self.args.append:
+                # read the current contents first, the new data is sent
+                # once they are received
+                self.request_from_clipboard()
+                return
+            self.write_to_clipboard(self.data_to_send)
+        self.after_write()
+
+    def after_write(self) -> None:
         if not self.args.get_clipboard:
             if self.args.wait_for_completion:
                 # ask kitty for the TN terminfo capability and
                 # only quit after a response is received
                 self.print('\x1bP+q544e\x1b\\', end='')
-                self.print('Waiting for completion...')
+                self.print('Waiting for completion of {} chunks...'.format(num_of_chunks(self.data_to_send)))
                 return
             self.quit_loop(0)
             return
-        self.cmd.request_from_clipboard(self.args.use_primary)
+        self.request_from_clipboard()
 
     def on_clipboard_response(self, text: str, from_primary: bool = False) -> None:
+        if self.args.append and self.data_to_send is not None:
+            if text:
+                data = text.encode('utf-8') + (self.args.append_separator or '').encode('utf-8') + self.data_to_send
+                # the joined data must respect the size limit as well
+                try:
+                    self.data_to_send = limited(data, self.args.max_size, self.args.on_oversize, self.print)
+                except SystemExit as err:
+                    self.error_message = str(err)
+                    self.quit_loop(1)
+                    return
+            self.write_to_clipboard(self.data_to_send)
+            self.after_write()
+            return
         self.clipboard_contents = text
         self.quit_loop(0)
 
@@ -69,6 +154,48 @@ def on_eot(self) -> None:
 type=bool-set
 Wait till the copy to clipboard is complete before exiting. Useful if running
 the kitten in a dedicated, ephemeral window.
+
+
+--append
+type=bool-set
+Add the text from STDIN to the end of the current contents of the clipboard
+rather than replacing them. Note that this requires reading the clipboard
+to be allowed in kitty.conf, otherwise the contents are replaced.
+
+
+--append-separator
+The text to insert between the current contents of the clipboard and the new
+text when using :option:`--append`. For example, to use a newline in bash:
+:code:`--append-separator=$'\n'`
+
+
+--max-size
+default=0
+type=int
+The maximum number of bytes of data to send to the clipboard. Zero means no
+limit. Note that terminals typically limit the size of data they accept via
+the OSC 52 escape code, so very large data may be silently dropped.
+
+
+--on-oversize
+default=error
+type=choices
+choices=error,truncate
+What to do when the data to be sent is larger than :option:`--max-size`.
+:code:`error` refuses to send anything and exits with an error,
+:code:`truncate` sends only the first :option:`--max-size` bytes
+and prints a warning.
+
+
+--passthrough
+default=detect
+type=choices
+choices=detect,tmux,none
+Wrap the escape codes used to read and write the clipboard so that they pass
+through a terminal multiplexer to the terminal it is running in. :code:`detect`
+wraps them when running inside tmux, :code:`tmux` always wraps them for tmux and
+:code:`none` never wraps them. Note that tmux must be configured to allow
+passthrough.
 '''.format
 help_text = '''\
 Read or write to the system clipboard.
@@ -86,13 +213,18 @@ def main(args: List[str]) -> NoReturn:
     cli_opts, items = parse_args(args[1:], OPTIONS, usage, help_text, 'kitty +kitten clipboard', result_class=ClipboardCLIOptions)
     if items:
         raise SystemExit('Unrecognized extra command line arguments')
+    if cli_opts.append and cli_opts.get_clipboard:
+        raise SystemExit('The --append and --get-clipboard options cannot be used together')
     data: Optional[bytes] = None
     if not sys.stdin.isatty():
         data = sys.stdin.buffer.read()
         sys.stdin = open(os.ctermid(), 'r')
+        data = limited(data, cli_opts.max_size, cli_opts.on_oversize)
     loop = Loop()
     handler = Clipboard(data, cli_opts)
     loop.loop(handler)
+    if handler.error_message:
+        raise SystemExit(handler.error_message)
     if loop.return_code == 0 and handler.clipboard_contents:
         sys.stdout.write(handler.clipboard_contents)
         sys.stdout.flush()
@@ -81,6 +81,22 @@ def parse_start_search(func: str, rest: str) -> Tuple[str, Tuple[bool, bool]]:
     return func, (is_regex, is_backward)
 
 
+@func_with_args('copy_change')
+def parse_copy_change(func: str, rest: str) -> Tuple[str, str]:
+    rest = rest.lower()
+    if rest not in {'old', 'new'}:
+        rest = 'new'
+    return func, rest
+
+
+@func_with_args('select_change')
+def parse_select_change(func: str, rest: str) -> Tuple[str, str]:
+    rest = rest.lower()
+    if rest not in {'next', 'prev'}:
+        rest = 'next'
+    return func, rest
+
+
 def special_handling(key: str, val: str, ans: Dict) -> bool:
     if key == 'map':
         x = parse_kittens_key(val, args_funcs)
@@ -9,7 +9,9 @@
 from typing import Any, Dict, Sequence, Union
 
 from kitty.conf.definition import Option, Shortcut, option_func
-from kitty.conf.utils import python_string, to_color, to_color_or_none
+from kitty.conf.utils import (
+    choices, python_string, to_color, to_color_or_none
+)
 from kitty.utils import positive_int
 
 # }}}
@@ -49,6 +51,12 @@ def syntax_aliases(raw: str) -> Dict[str, str]:
 is to search the system for either git or diff and use that, if found.
 '''))
 
+o('ignore_whitespace', 'none', option_type=choices('none', 'trailing', 'all'), long_text=_('''
+Ignore changes in whitespace when computing the diff. A value of :code:`trailing`
+ignores only whitespace changes at the end of lines, :code:`all` ignores all
+whitespace changes, so that lines differing only in whitespace are shown as
+unchanged. Only works when the diff command is git or diff.'''))
+
 o('replace_tab_by', r'\x20\x20\x20\x20', option_type=python_string, long_text=_('''
 The string to replace tabs with. Default is to use four spaces.'''))
 
@@ -114,6 +122,13 @@ def syntax_aliases(raw: str) -> Dict[str, str]:
 k('increase_context', '+', 'change_context 5', _('Increase context'))
 k('decrease_context', '-', 'change_context -5', _('Decrease context'))
 
+k('toggle_ignore_whitespace', 'w', 'toggle_ignore_whitespace', _('Toggle ignoring of whitespace changes'))
+
+k('select_next_change', 'shift+n', 'select_change next', _('Extend the selection to the next change'))
+k('select_prev_change', 'shift+p', 'select_change prev', _('Extend the selection to the previous change'))
+k('copy_new_change', 'y', 'copy_change new', _('Copy the new text of the selected changes to the clipboard'))
+k('copy_old_change', 'shift+y', 'copy_change old', _('Copy the old text of the selected changes to the clipboard'))
+
 k('search_forward', '/', 'start_search regex forward', _('Search forward'))
 k('search_backward', '?', 'start_search regex backward', _('Search backward'))
 k('next_match', '.', 'scroll_to next-match', _('Scroll to next search match'))
@@ -39,7 +39,8 @@
 from .config import init_config
 from .patch import Differ, Patch, set_diff_command, worker_processes
 from .render import (
-    ImagePlacement, ImageSupportWarning, Line, LineRef, Reference, render_diff
+    ChangeRef, ImagePlacement, ImageSupportWarning, Line, LineRef, Reference,
+    render_diff
 )
 from .search import BadRegex, Search
 
@@ -59,7 +60,7 @@ def highlight_collection(collection: 'Collection', aliases: Optional[Dict[str, s
 INITIALIZING, COLLECTED, DIFFED, COMMAND, MESSAGE = range(5)
 
 
-def generate_diff(collection: Collection, context: int) -> Union[str, Dict[str, Patch]]:
+def generate_diff(collection: Collection, context: int, ignore_whitespace: str = 'none') -> Union[str, Dict[str, Patch]]:
     d = Differ()
 
     for path, item_type, changed_path in collection:
@@ -69,7 +70,7 @@ def generate_diff(collection: Collection, context: int) -> Union[str, Dict[str,
                 assert changed_path is not None
                 d.add_diff(path, changed_path)
 
-    return d(context)
+    return d(context, ignore_whitespace)
 
 
 class DiffHandler(Handler):
@@ -90,8 +91,13 @@ def __init__(self, args: DiffCLIOptions, opts: DiffOptions, left: str, right: st
         self.current_context_count = self.original_context_count = self.args.context
         if self.current_context_count < 0:
             self.current_context_count = self.original_context_count = self.opts.num_context_lines
+        self.current_ignore_whitespace = self.opts.ignore_whitespace
         self.highlighting_done = False
         self.restore_position: Optional[Reference] = None
+        # indices into change_lines of the anchor and end of the selected changes
+        self.selection: Optional[Tuple[int, int]] = None
+        self.change_lines: List[int] = []
+        self.selection_style = styled('|', fg=self.opts.select_fg, bg=self.opts.select_bg).split('|', 1)[0]
         for key_def, action in self.opts.key_definitions.items():
             self.add_shortcut(action, key_def)
 
@@ -124,9 +130,19 @@ def perform_action(self, action: KittensKeyAction) -> None:
                 else:
                     new_ctx += int(to)
                 return self.change_context_count(new_ctx)
+            if func == 'toggle_ignore_whitespace':
+                if self.current_ignore_whitespace == 'none':
+                    new_mode = 'all' if self.opts.ignore_whitespace == 'none' else self.opts.ignore_whitespace
+                else:
+                    new_mode = 'none'
+                return self.change_ignore_whitespace(new_mode)
             if func == 'start_search':
                 self.start_search(bool(args[0]), bool(args[1]))
                 return
+            if func == 'select_change':
+                return self.extend_selection(backwards=str(args[0]) == 'prev')
+            if func == 'copy_change':
+                return self.copy_change(str(args[0]) == 'old')
 
     def create_collection(self) -> None:
 
@@ -168,11 +184,11 @@ def diff_done(diff_map: Union[str, Dict[str, Patch]]) -> None:
                     return
                 self.syntax_highlight()
 
-        def diff(collection: Collection, current_context_count: int) -> None:
-            diff_map = generate_diff(collection, current_context_count)
+        def diff(collection: Collection, current_context_count: int, ignore_whitespace: str) -> None:
+            diff_map = generate_diff(collection, current_context_count, ignore_whitespace)
             self.asyncio_loop.call_soon_threadsafe(diff_done, diff_map)
 
-        self.asyncio_loop.run_in_executor(None, diff, self.collection, self.current_context_count)
+        self.asyncio_loop.run_in_executor(None, diff, self.collection, self.current_context_count, self.current_ignore_whitespace)
 
     def syntax_highlight(self) -> None:
 
@@ -199,11 +215,19 @@ def calculate_statistics(self) -> None:
             self.removed_count += patch.removed_count
 
     def render_diff(self) -> None:
+        selected = self.selected_changes
         self.diff_lines: Tuple[Line, ...] = tuple(render_diff(self.collection, self.diff_map, self.args, self.screen_size.cols, self.image_manager))
         self.margin_size = render_diff.margin_size
         self.ref_path_map: DefaultDict[str, List[Tuple[int, Reference]]] = defaultdict(list)
         for i, l in enumerate(self.diff_lines):
             self.ref_path_map[l.ref.path].append((i, l.ref))
+        self.change_lines = [i for i, l in enumerate(self.diff_lines) if l.is_change_start]
+        self.selection = None
+        if selected:
+            positions = {self.diff_lines[i].change: n for n, i in enumerate(self.change_lines)}
+            anchor, end = positions.get(selected[0]), positions.get(selected[-1])
+            if anchor is not None and end is not None:
+                self.selection = anchor, end
         self.max_scroll_pos = len(self.diff_lines) - self.num_lines
         if self.current_search is not None:
             self.current_search(self.diff_lines, self.margin_size, self.screen_size.cols)
@@ -244,10 +268,80 @@ def scroll_to_next_change(self, backwards: bool = False) -> None:
         for i in r:
             line = self.diff_lines[i]
             if line.is_change_start:
+                n = self.change_lines.index(i)
+                self.selection = n, n
                 self.scroll_lines(i - self.scroll_pos)
                 return
         self.cmd.bell()
 
+    @property
+    def selected_changes(self) -> List[ChangeRef]:
+        if self.selection is None:
+            return []
+        anchor, end = self.selection
+        step = 1 if end >= anchor else -1
+        ans = []
+        for n in range(anchor, end + step, step):
+            change = self.diff_lines[self.change_lines[n]].change
+            if change is not None:
+                ans.append(change)
+        return ans
+
+    def selected_line_range(self) -> Optional[Tuple[int, int]]:
+        if self.selection is None:
+            return None
+        first, last = map(self.change_lines.__getitem__, sorted(self.selection))
+        change = self.diff_lines[last].change
+        while last + 1 < len(self.diff_lines) and self.diff_lines[last + 1].change is change:
+            last += 1
+        return first, last
+
+    def extend_selection(self, backwards: bool = False) -> None:
+        if self.selection is None:
+            for n, i in enumerate(self.change_lines):
+                if i >= self.scroll_pos:
+                    break
+            else:
+                self.cmd.bell()
+                return
+            self.selection = n, n
+        else:
+            anchor, end = self.selection
+            n = end + (-1 if backwards else 1)
+            if not 0 <= n < len(self.change_lines):
+                self.cmd.bell()
+                return
+            a, b = self.diff_lines[self.change_lines[anchor]].change, self.diff_lines[self.change_lines[n]].change
+            if a is None or b is None or (a.left_path, a.right_path) != (b.left_path, b.right_path):
+                # selections cannot span files
+                self.cmd.bell()
+                return
+            self.selection = anchor, n
+        i = self.change_lines[n]
+        if i < self.scroll_pos or i >= self.scroll_pos + self.num_lines:
+            self.scroll_lines(i - self.scroll_pos)
+        else:
+            self.draw_screen()
+
+    def clear_selection(self) -> None:
+        self.selection = None
+        self.draw_screen()
+
+    def copy_change(self, old: bool = False) -> None:
+        changes = sorted(self.selected_changes, key=lambda c: (c.left_start, c.right_start))
+        if changes:
+            first, last = changes[0], changes[-1]
+            path, start, end = (
+                (first.left_path, first.left_start, last.left_start + last.left_count) if old else
+                (first.right_path, first.right_start, last.right_start + last.right_count))
+            if path and end > start:
+                data = data_for_path(path)
+                if isinstance(data, str):
+                    text = ''.join(data.splitlines(keepends=True)[start:end])
+                    self.cmd.write_to_clipboard(text)
+                    return
+        self.cmd.bell()
+
     def scroll_to_next_match(self, backwards: bool = False, include_current: bool = False) -> None:
         if self.current_search is not None:
             offset = 0 if include_current else 1
@@ -311,6 +405,7 @@ def draw_lines(self, num: int, offset: int = 0) -> None:
         offset += self.scroll_pos
         image_involved = False
         limit = len(self.diff_lines)
+        selected = self.selected_line_range() or (limit, limit)
         for i in range(num):
             lpos = offset + i
             if lpos >= limit:
@@ -323,11 +418,18 @@ def draw_lines(self, num: int, offset: int = 0) -> None:
             self.write('\r\x1b[K' + text + '\x1b[0m')
             if self.current_search is not None:
                 self.current_search.highlight_line(self.write, lpos)
+            if selected[0] <= lpos <= selected[1]:
+                self.draw_selection_marker()
             if i < num - 1:
                 self.write('\n')
         if image_involved:
             self.place_images()
 
+    def draw_selection_marker(self) -> None:
+        # mark the last cell of the left and right margins, which are always blank
+        col = self.margin_size - 1
+        self.write('{}\r\x1b[{}C \r\x1b[{}C \x1b[m'.format(self.selection_style, col, self.screen_size.cols // 2 + col))
+
     def update_image_placement_for_resend(self, image_id: int, pl: Placement) -> bool:
         offset = self.scroll_pos
         limit = len(self.diff_lines)
@@ -440,6 +542,8 @@ def draw_status_line(self) -> None:
                 counts = styled('{} matches'.format(len(self.current_search)), fg=self.opts.margin_fg)
             suffix = counts + '  ' + scroll_frac
             prefix = styled(':', fg=self.opts.margin_fg)
+            if self.current_ignore_whitespace != 'none':
+                prefix += styled(' ' + _('ignoring {} whitespace').format(self.current_ignore_whitespace), fg=self.opts.margin_fg)
             filler = self.screen_size.cols - wcswidth(prefix) - wcswidth(suffix)
             text = '{}{}{}'.format(prefix, ' ' * filler, suffix)
             self.write(text)
@@ -453,6 +557,14 @@ def change_context_count(self, new_ctx: int) -> None:
             self.restore_position = self.current_position
             self.draw_screen()
 
+    def change_ignore_whitespace(self, new_mode: str) -> None:
+        if new_mode != self.current_ignore_whitespace:
+            self.current_ignore_whitespace = new_mode
+            self.state = COLLECTED
+            self.generate_diff()
+            self.restore_position = self.current_position
+            self.draw_screen()
+
     def start_search(self, is_regex: bool, is_backward: bool) -> None:
         if self.state != DIFFED:
             self.cmd.bell()
@@ -518,6 +630,8 @@ def on_key_event(self, key_event: KeyEvent, in_bracketed_paste: bool = False) ->
                 self.current_search = None
                 self.draw_screen()
                 return
+            if self.state >= DIFFED and self.selection is not None and key_event.matches('esc'):
+                return self.clear_selection()
             if key_event.type is EventType.RELEASE:
                 return
         action = self.shortcut_action(key_event)
@@ -571,7 +685,10 @@ def __call__(self, message: Any, category: Any, filename: str, lineno: int, file
 
 
 showwarning = ShowWarning()
-help_text = 'Show a side-by-side diff of the specified files/directories. You can also use ssh:hostname:remote-file-path to diff remote files.'
+help_text = (
+    'Show a side-by-side diff of the specified files/directories. You can also use ssh:hostname:remote-file-path to diff remote files'
+    ' and git:revision:path to diff files or directories as they were at the specified git revision.'
+)
 usage = 'file_or_directory_left file_or_directory_right'
 
 
@@ -609,11 +726,49 @@ def get_ssh_file(hostname: str, rpath: str) -> str:
         return os.path.abspath(os.path.join(tdir, rpath))
 
 
-def get_remote_file(path: str) -> str:
+def get_git_file(rev: str, path: str) -> str:
+    import io
+    import shutil
+    import tarfile
+    top = subprocess.check_output(['git', 'rev-parse', '--show-toplevel']).decode('utf-8').rstrip('\n')
+    # paths are relative to the current directory, archive them relative
+    # to the top of the repository so that absolute paths work as well
+    rpath = os.path.relpath(os.path.realpath(os.path.abspath(path)), os.path.realpath(top))
+    if rpath == os.pardir or rpath.startswith(os.pardir + os.sep):
+        raise SystemExit('The path {} is not inside the git repository at {}'.format(path, top))
+    rpath = rpath.replace(os.sep, '/')
+    tdir = tempfile.mkdtemp(suffix='-git')
+    add_remote_dir(tdir)
+    atexit.register(shutil.rmtree, tdir)
+    p = subprocess.Popen(['git', 'archive', '--format=tar', rev, '--', rpath], stdout=subprocess.PIPE, cwd=top)
+    assert p.stdout is not None
+    raw = p.stdout.read()
+    if p.wait() != 0:
+        raise SystemExit(p.returncode)
+    with tarfile.open(fileobj=io.BytesIO(raw), mode='r:') as tf:
+        tf.extractall(tdir)
+    return os.path.abspath(os.path.join(tdir, rpath))
+
+
+def git_spec(path: str) -> Tuple[str, str]:
+    rev, sep, rpath = path[4:].partition(':')
+    return rev, rpath
+
+
+def get_remote_file(path: str, other: str = '') -> str:
     if path.startswith('ssh:'):
         parts = path.split(':', 2)
         if len(parts) == 3:
             return get_ssh_file(parts[1], parts[2])
+    if path.startswith('git:'):
+        rev, rpath = git_spec(path)
+        if not rpath:
+            # use the path from the other item so that two revisions
+            # of the same path can be compared
+            rpath = git_spec(other)[1] if other.startswith('git:') else other
+        if not rev or not rpath:
+            raise SystemExit('Invalid git specification: {}, must be of the form git:revision:path'.format(path))
+        return get_git_file(rev, rpath)
     return path
 
 
@@ -627,7 +782,7 @@ def main(args: List[str]) -> None:
     opts = init_config(cli_opts)
     set_diff_command(opts.diff_cmd)
     lines_for_path.replace_tab_by = opts.replace_tab_by
-    left, right = map(get_remote_file, (left, right))
+    left, right = get_remote_file(left, right), get_remote_file(right, left)
     if os.path.isdir(left) != os.path.isdir(right):
         raise SystemExit('The items to be diffed should both be either directories or files. Comparing a directory to a file is not valid.')
     for f in left, right:
@@ -37,9 +37,24 @@ def set_diff_command(opt: str) -> None:
     global_data.cmd = cmd
 
 
-def run_diff(file1: str, file2: str, context: int = 3) -> Tuple[bool, Union[int, bool], str]:
+def whitespace_args(cmd: Sequence[str], ignore_whitespace: str) -> List[str]:
+    if ignore_whitespace == 'none' or not cmd:
+        return []
+    exe = os.path.basename(cmd[0])
+    if exe == 'git':
+        return ['--ignore-space-at-eol'] if ignore_whitespace == 'trailing' else ['--ignore-all-space']
+    if exe == 'diff':
+        return ['--ignore-trailing-space'] if ignore_whitespace == 'trailing' else ['--ignore-all-space']
+    return []
+
+
+def run_diff(file1: str, file2: str, context: int = 3, ignore_whitespace: str = 'none') -> Tuple[bool, Union[int, bool], str]:
     # returns: ok, is_different, patch
     cmd = shlex.split(global_data.cmd.replace('_CONTEXT_', str(context)))
+    ws_args = whitespace_args(cmd, ignore_whitespace)
+    if ws_args:
+        idx = cmd.index('--') if '--' in cmd else len(cmd)
+        cmd[idx:idx] = ws_args
     # we resolve symlinks because git diff does not follow symlinks, while diff
     # does. We want consistent behavior, also for integration with git difftool
     # we always want symlinks to be followed.
@@ -228,12 +243,12 @@ def add_diff(self, file1: str, file2: str) -> None:
         self.jmap[file1] = file2
         self.jobs.append(file1)
 
-    def __call__(self, context: int = 3) -> Union[str, Dict[str, Patch]]:
+    def __call__(self, context: int = 3, ignore_whitespace: str = 'none') -> Union[str, Dict[str, Patch]]:
         global left_lines, right_lines
         ans: Dict[str, Patch] = {}
         executor = self.diff_executor
         assert executor is not None
-        jobs = {executor.submit(run_diff, key, self.jmap[key], context): key for key in self.jobs}
+        jobs = {executor.submit(run_diff, key, self.jmap[key], context, ignore_whitespace): key for key in self.jobs}
         for future in concurrent.futures.as_completed(jobs):
             key = jobs[future]
             left_path, right_path = key, self.jmap[key]
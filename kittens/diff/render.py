@@ -67,21 +67,48 @@ def __init__(self, path: str, extra: Optional[LineRef] = None) -> None:
         object.__setattr__(self, 'extra', extra)
 
 
+class ChangeRef(Ref):
+
+    __slots__ = ('left_path', 'left_start', 'left_count', 'right_path', 'right_start', 'right_count')
+    left_path: str
+    left_start: int
+    left_count: int
+    right_path: str
+    right_start: int
+    right_count: int
+
+    def __init__(self, left_path: str, left_start: int, left_count: int, right_path: str, right_start: int, right_count: int) -> None:
+        object.__setattr__(self, 'left_path', left_path)
+        object.__setattr__(self, 'left_start', left_start)
+        object.__setattr__(self, 'left_count', left_count)
+        object.__setattr__(self, 'right_path', right_path)
+        object.__setattr__(self, 'right_start', right_start)
+        object.__setattr__(self, 'right_count', right_count)
+
+    def __eq__(self, other: object) -> bool:
+        return isinstance(other, ChangeRef) and all(getattr(self, x) == getattr(other, x) for x in self.__slots__)
+
+    def __hash__(self) -> int:
+        return hash(tuple(getattr(self, x) for x in self.__slots__))
+
+
 class Line:
 
-    __slots__ = ('text', 'ref', 'is_change_start', 'image_data')
+    __slots__ = ('text', 'ref', 'is_change_start', 'image_data', 'change')
 
     def __init__(
         self,
         text: str,
         ref: Reference,
         change_start: bool = False,
-        image_data: Optional[Tuple[Optional['ImagePlacement'], Optional['ImagePlacement']]] = None
+        image_data: Optional[Tuple[Optional['ImagePlacement'], Optional['ImagePlacement']]] = None,
+        change: Optional[ChangeRef] = None
     ) -> None:
         self.text = text
         self.ref = ref
         self.is_change_start = change_start
         self.image_data = image_data
+        self.change = change
 
 
 def yield_lines_from(iterator: Iterable[str], reference: Reference, is_change_start: bool = True) -> Generator[Line, None, None]:
@@ -320,6 +347,9 @@ def lines_for_chunk(data: DiffData, hunk_num: int, chunk: Chunk, chunk_num: int)
                 left_line_number_s = right_line_number_s = ''
     else:
         common = min(chunk.left_count, chunk.right_count)
+        change = ChangeRef(
+            data.left_path, chunk.left_start, chunk.left_count,
+            data.right_path, chunk.right_start, chunk.right_count)
         for i in range(max(chunk.left_count, chunk.right_count)):
             ll: List[str] = []
             rl: List[str] = []
@@ -354,7 +384,7 @@ def lines_for_chunk(data: DiffData, hunk_num: int, chunk: Chunk, chunk_num: int)
                 x.extend(repeat(data.filler_line, count))
             for wli, (left_line, right_line) in enumerate(zip(ll, rl)):
                 ref = Reference(ref_path, LineRef(ref_ln, wli))
-                yield Line(left_line + right_line, ref, i == 0 and wli == 0)
+                yield Line(left_line + right_line, ref, i == 0 and wli == 0, change=change)
 
 
 def lines_for_diff(left_path: str, right_path: str, hunks: Iterable[Hunk], args: DiffCLIOptions, columns: int, margin_size: int) -> Generator[Line, None, None]:
@@ -374,6 +404,7 @@ def all_lines(path: str, args: DiffCLIOptions, columns: int, margin_size: int, i
     filler = render_diff_line('', '', 'filler', margin_size, available_cols)
     msg_written = False
     hdata = highlights_for_path(path)
+    change = ChangeRef('', 0, 0, path, 0, len(lines)) if is_add else ChangeRef(path, 0, len(lines), '', 0, 0)
 
     def highlights(num: int) -> List[Segment]:
         return hdata[num] if num < len(hdata) else []
@@ -389,7 +420,7 @@ def highlights(num: int) -> List[Segment]:
                         '', _('This file was added') if is_add else _('This file was removed'),
                         'filler', margin_size, available_cols)
             text = (empty + hl) if is_add else (hl + empty)
-            yield Line(text, ref, line_number == 0 and i == 0)
+            yield Line(text, ref, line_number == 0 and i == 0, change=change)
 
 
 def rename_lines(path: str, other_path: str, args: DiffCLIOptions, columns: int, margin_size: int) -> Generator[str, None, None]:
@@ -106,6 +106,30 @@ def render(text: str, current_input: str, all_marks: Sequence[Mark], ignore_mark
     return text.replace('\n', '\r\n').rstrip()
 
 
+def describe_action(m: Mark, args: HintsCLIOptions) -> str:
+    if args.type == 'linenum':
+        path, line = m.groupdict.get('path'), m.groupdict.get('line')
+        target = '{}:{}'.format(path, line) if path and line else m.text
+        return '{}: {}'.format(args.linenum_action, target)
+    if m.groupdict and args.type != 'url':
+        target = ' '.join('{}={}'.format(k, v or '') for k, v in m.groupdict.items())
+    else:
+        target = m.text
+    descriptions = []
+    for program in args.program or ('default',):
+        if program == '-':
+            descriptions.append(_('Paste'))
+        elif program == '@':
+            descriptions.append(_('Copy'))
+        elif program == '*':
+            descriptions.append(_('Copy to selection'))
+        elif program == 'default':
+            descriptions.append(_('Open'))
+        else:
+            descriptions.append(program)
+    return '{}: {}'.format(', '.join(descriptions), target)
+
+
 class Hints(Handler):
 
     def __init__(self, text: str, all_marks: Sequence[Mark], index_map: Dict[int, Mark], args: HintsCLIOptions):
@@ -162,7 +186,13 @@ def on_text(self, text: str, in_bracketed_paste: bool = False) -> None:
                 m for idx, m in self.index_map.items()
                 if encode_hint(idx, self.alphabet).startswith(self.current_input)
             ]
-            if len(matches) == 1:
+            if self.args.confirm == 'never':
+                # select a complete hint even if it is the start of longer hints
+                matches = [
+                    m for m in matches
+                    if encode_hint(m.index, self.alphabet) == self.current_input
+                ] or matches
+            if len(matches) == 1 and self.args.confirm != 'always':
                 self.chosen.append(matches[0])
                 if self.multiple:
                     self.ignore_mark_indices.add(matches[0].index)
@@ -210,6 +240,27 @@ def draw_screen(self) -> None:
             self.current_text = render(self.text, self.current_input, self.all_marks, self.ignore_mark_indices, self.alphabet, self.colors)
         self.cmd.clear_screen()
         self.write(self.current_text)
+        if self.args.show_action:
+            self.draw_action()
+
+    def draw_action(self) -> None:
+        matches = [
+            m for idx, m in self.index_map.items()
+            if idx not in self.ignore_mark_indices and encode_hint(idx, self.alphabet).startswith(self.current_input)
+        ]
+        if not self.current_input or not matches:
+            return
+        exact = [m for m in matches if encode_hint(m.index, self.alphabet) == self.current_input]
+        if exact:
+            text = describe_action(exact[0], self.args)
+        elif len(matches) == 1:
+            text = describe_action(matches[0], self.args)
+        else:
+            text = _('{} matching hints').format(len(matches))
+        text = text.replace('\n', ' ')[:max(0, self.screen_size.cols - 1)]
+        self.cmd.set_cursor_position(0, self.screen_size.rows - 1)
+        self.cmd.clear_to_eol()
+        self.write(styled(text, reverse=True))
 
 
 def regex_finditer(pat: Pattern, minimum_match_length: int, text: str) -> Generator[Tuple[int, int, Dict], None, None]:
@@ -310,11 +361,40 @@ def mark(pattern: str, post_processors: Iterable[PostprocessorFunc], text: str,
         yield Mark(idx, s, e, mark_text, groupdict)
 
 
+def load_history(hint_type: str) -> List[str]:
+    from kitty.config import cached_values_for
+    with cached_values_for('hints-history') as cached_values:
+        return list(cached_values.get(hint_type, ()))
+
+
+def add_to_history(hint_type: str, chosen: Iterable[str], max_size: int) -> None:
+    from kitty.config import cached_values_for
+    with cached_values_for('hints-history') as cached_values:
+        history = list(cached_values.get(hint_type, ()))
+        for text in chosen:
+            if text in history:
+                history.remove(text)
+            history.insert(0, text)
+        cached_values[hint_type] = history[:max_size]
+
+
+def history_marks(history: Sequence[str], cols: int, rows: int = 0) -> Tuple[str, Tuple[Mark, ...]]:
+    text = convert_text('\n'.join(history), cols)
+    if rows:
+        text = reserve_last_line(text, rows)
+    marks = tuple(
+        Mark(i, m.start(), m.end(), m.group(), {})
+        for i, m in enumerate(re.finditer(r'[^\0\n]+', text)))
+    return text, marks
+
+
 def run_loop(args: HintsCLIOptions, text: str, all_marks: Sequence[Mark], index_map: Dict[int, Mark], extra_cli_args: Sequence[str] = ()) -> Dict[str, Any]:
     loop = Loop()
     handler = Hints(text, all_marks, index_map, args)
     loop.loop(handler)
     if handler.chosen and loop.return_code == 0:
+        if args.history_size > 0:
+            add_to_history(args.type, (m.text for m in handler.chosen), args.history_size)
         return {
             'match': handler.text_matches, 'programs': args.program,
             'multiple_joiner': args.multiple_joiner, 'customize_processing': args.customize_processing,
@@ -382,12 +462,31 @@ def convert_text(text: str, cols: int) -> str:
     return '\n'.join(lines)
 
 
-def parse_input(text: str) -> str:
+def screen_cols() -> int:
+    try:
+        return int(os.environ['OVERLAID_WINDOW_COLS'])
+    except KeyError:
+        return screen_size_function()().cols
+
+
+def screen_rows() -> int:
     try:
-        cols = int(os.environ['OVERLAID_WINDOW_COLS'])
+        return int(os.environ['OVERLAID_WINDOW_LINES'])
     except KeyError:
-        cols = screen_size_function()().cols
-    return convert_text(text, cols)
+        return screen_size_function()().rows
+
+
+def parse_input(text: str) -> str:
+    return convert_text(text, screen_cols())
+
+
+def reserve_last_line(text: str, rows: int) -> str:
+    # remove lines from the bottom so that the last screen row is free for
+    # the status line and does not cover any hints
+    lines = text.split('\n')
+    if len(lines) >= rows:
+        text = '\n'.join(lines[:max(0, rows - 1)])
+    return text
 
 
 def linenum_marks(text: str, args: HintsCLIOptions, Mark: Type[Mark], extra_cli_args: Sequence[str], *a: Any) -> Generator[Mark, None, None]:
@@ -397,6 +496,30 @@ def linenum_marks(text: str, args: HintsCLIOptions, Mark: Type[Mark], extra_cli_
     yield from mark(regex, [brackets, quotes], text, args)
 
 
+def parse_region(spec: str) -> Tuple[int, int, int, int]:
+    try:
+        x1, y1, x2, y2 = map(int, spec.split(','))
+    except Exception:
+        raise ValueError('Invalid region specification: {}, must be of the form x1,y1,x2,y2'.format(spec))
+    return min(x1, x2), min(y1, y2), max(x1, x2), max(y1, y2)
+
+
+def marks_in_region(text: str, all_marks: Sequence[Mark], region: Tuple[int, int, int, int]) -> Tuple[Mark, ...]:
+    from bisect import bisect_right
+    line_starts = [0] + [m.end() for m in re.finditer('\n', text)]
+    x1, y1, x2, y2 = region
+
+    def in_region(pos: int) -> bool:
+        y = bisect_right(line_starts, pos) - 1
+        x = pos - line_starts[y]
+        return x1 <= x <= x2 and y1 <= y <= y2
+
+    ans = tuple(m for m in all_marks if in_region(m.start) and in_region(max(m.start, m.end - 1)))
+    for i, m in enumerate(ans):
+        m.index = i
+    return ans
+
+
 def load_custom_processor(customize_processing: str) -> Any:
     if customize_processing.startswith('::import::'):
         import importlib
@@ -463,10 +586,17 @@ def process_hyperlink(m: 're.Match') -> str:
 def run(args: HintsCLIOptions, text: str, extra_cli_args: Sequence[str] = ()) -> Optional[Dict[str, Any]]:
     try:
         text = parse_input(remove_sgr(text))
+        if args.show_action:
+            text = reserve_last_line(text, screen_rows())
         text, hyperlinks = process_hyperlinks(text)
         pattern, post_processors = functions_for(args)
         if args.type == 'linenum':
             args.customize_processing = '::linenum::'
+        if args.from_history:
+            # the history entries replace the text, they are then matched
+            # as usual so that things like linenum groups work
+            text, hyperlinks = history_marks(
+                load_history(args.type), screen_cols(), screen_rows() if args.show_action else 0)
         if args.type == 'hyperlink':
             all_marks = hyperlinks
         elif args.customize_processing:
@@ -477,8 +607,12 @@ def run(args: HintsCLIOptions, text: str, extra_cli_args: Sequence[str] = ()) ->
                 all_marks = tuple(mark(pattern, post_processors, text, args))
         else:
             all_marks = tuple(mark(pattern, post_processors, text, args))
+        if args.region:
+            all_marks = marks_in_region(text, all_marks, parse_region(args.region))
         if not all_marks:
             none_of = {'url': 'URLs', 'hyperlink': 'hyperlinks'}.get(args.type, 'matches')
+            if args.from_history:
+                none_of = 'previously selected ' + none_of
             input(_('No {} found, press Enter to quit.').format(none_of))
             return None
 
@@ -572,6 +706,18 @@ def run(args: HintsCLIOptions, text: str, extra_cli_args: Sequence[str] = ()) ->
 In this mode, press :kbd:`Esc` to finish selecting.
 
 
+--confirm
+default=auto
+type=choices
+choices=auto,always,never
+When to select a match. The default of auto selects a match as soon as enough
+of its hint has been typed to uniquely identify it, needing :kbd:`Enter` only
+when the typed hint is also the start of longer hints. With always, you
+have to press :kbd:`Enter` to select a match, after typing its hint. With
+never, a match is also selected as soon as its complete hint is typed, even
+when it is the start of longer hints.
+
+
 --multiple-joiner
 default=auto
 String to use to join multiple selections when copying to the clipboard or
@@ -637,6 +783,36 @@ def run(args: HintsCLIOptions, text: str, extra_cli_args: Sequence[str] = ()) ->
 --window-title
 The window title for the hints window, default title is selected based on
 the type of text being hinted.
+
+
+--show-action
+type=bool-set
+Show a status line at the bottom of the screen describing the action that will
+be performed and its target, for the hint matching the currently typed
+characters. For example, the full URL to be opened or the :code:`path:line`
+to be jumped to. The last line of the screen is reserved for the status line,
+so no hints are shown on it.
+
+
+--region
+Only look for matches inside the specified rectangular region of the screen.
+The region is specified as :code:`x1,y1,x2,y2` where the numbers are zero based
+column and line numbers of opposite corners of the rectangle, inclusive.
+Matches that do not both start and end inside the region are ignored.
+
+
+--history-size
+type=int
+default=0
+Remember up to this many of the most recently selected matches for each
+:option:`--type`, so that they can be selected again with
+:option:`--from-history`. Zero disables the history.
+
+
+--from-history
+type=bool-set
+Instead of looking for matches in the text, offer the previously selected
+matches for the current :option:`--type` remembered via :option:`--history-size`.
 '''.format(
     default_regex=DEFAULT_REGEX,
     line='{{line}}', path='{{path}}'
@@ -20,7 +20,34 @@ def write_hyperlink(write: Callable[[bytes], None], url: bytes, line: bytes, fra
     write(text)
 
 
+def add_hyperlinks_to_json(p: 'subprocess.Popen[bytes]', hostname: bytes) -> None:
+    import json
+    assert p.stdout is not None
+    write = sys.stdout.buffer.write
+    for line in p.stdout:
+        try:
+            ev = json.loads(line)
+            data = ev['data']
+            path = data['path']['text']
+        except Exception:
+            # not an event with a textual path, pass it through unchanged
+            write(line)
+            continue
+        url = b'file://' + hostname + quote_from_bytes(os.path.abspath(path).encode('utf-8')).encode('utf-8')
+        if ev.get('type') in ('match', 'context') and data.get('line_number'):
+            url += b'#' + str(data['line_number']).encode('ascii')
+        data['hyperlink'] = url.decode('utf-8')
+        write(json.dumps(ev, ensure_ascii=False, separators=(',', ':')).encode('utf-8') + b'\n')
+
+
 def main() -> None:
+    if '--json' in sys.argv:
+        p = subprocess.Popen(['rg'] + sys.argv[1:], stdout=subprocess.PIPE)
+        try:
+            add_hyperlinks_to_json(p, socket.gethostname().encode('utf-8'))
+        except KeyboardInterrupt:
+            p.send_signal(signal.SIGINT)
+        raise SystemExit(p.wait())
     if not sys.stdout.isatty() and '--pretty' not in sys.argv:
         os.execlp('rg', 'rg', *sys.argv[1:])
     cmdline = ['rg', '--pretty', '--with-filename'] + sys.argv[1:]
@@ -11,7 +11,7 @@
 import zlib
 from base64 import standard_b64encode
 from math import ceil
-from tempfile import NamedTemporaryFile
+from tempfile import NamedTemporaryFile, mkstemp
 from typing import (
     Dict, Generator, List, NamedTuple, Optional, Pattern, Tuple, Union
 )
@@ -27,9 +27,9 @@
 )
 
 from ..tui.images import (
-    ConvertFailed, Dispose, GraphicsCommand, NoImageMagick, OpenFailed,
-    OutdatedImageMagick, RenderedImage, fsenc, identify,
-    render_as_single_image, render_image
+    ConvertFailed, Dispose, GraphicsCommand, ImageData, NoImageMagick,
+    OpenFailed, OutdatedImageMagick, RenderedImage, fsenc, identify,
+    render_as_single_image, render_image, rendered_size, transform_image
 )
 from ..tui.operations import clear_images_on_screen, raw_mode
 
@@ -103,7 +103,8 @@
 
 --silent
 type=bool-set
-Do not print out anything to STDOUT during operation.
+Do not print out anything to STDOUT during operation. Also suppresses the
+progress report shown on STDERR when streaming large images.
 
 
 --z-index -z
@@ -121,14 +122,89 @@
 is looped the specified number of times.
 
 
+--no-loop
+type=bool-set
+Play animations only once, stopping at the last frame. The same as using one
+for :option:`--loop`.
+
+
+--frame-delay
+type=int
+default=-1
+Override the delay (in milliseconds) between the frames of animations. A
+negative value means use the delays specified in the image. The smallest
+delay is one millisecond.
+
+
+--speed
+type=float
+default=1
+Multiply the playback speed of animations by this factor. For example, 2 plays
+animations twice as fast and 0.5 at half speed. Ignored when
+:option:`--frame-delay` is specified.
+
+
 --hold
 type=bool-set
 Wait for a key press before exiting after displaying the images.
+
+
+--trim-borders
+default=none
+Remove a uniform border around the image before displaying it. A value of
+:italic:`auto` detects the border color from the corners of the image, any
+other value is interpreted as the color of the border to remove, for example,
+:italic:`white` or :italic:`#fafafa`. If the image has no such border, it is
+displayed unchanged. Animated images are not trimmed.
+
+
+--trim-tolerance
+type=float
+default=10
+The percentage by which the color of a pixel can differ from the border color
+and still be considered part of the border, when using :option:`--trim-borders`.
+
+
+--rotate
+type=choices
+choices=0,90,180,270
+default=0
+Rotate the image clockwise by the specified number of degrees before displaying
+it. Any rotation specified in the EXIF metadata of the image is applied first,
+unless :option:`--no-exif-orientation` is used. The size of the displayed image
+is calculated from the rotated image.
+
+
+--no-exif-orientation
+type=bool-set
+Do not rotate or mirror images according to the orientation specified in their
+EXIF metadata.
+
+
+--mirror
+type=choices
+choices=none,horizontal,vertical,both
+default=none
+Mirror the image horizontally, vertically or both, after any rotation has been
+applied.
+
+
+--print-placement
+type=choices
+choices=none,text,json
+default=none
+Do not display the image, instead print out where it would be placed, given the
+other options. The output is the area it would occupy in cells, in the same
+syntax as :option:`--place` and its size and position in pixels, either as text
+or as a JSON object. When not using :option:`--place`, positions are relative
+to the start of the line the cursor is on.
 '''
 
 
 screen_size: Optional[ScreenSizeGetter] = None
 can_transfer_with_files = False
+show_progress = False
+PROGRESS_THRESHOLD = 1024 * 1024
 
 
 def get_screen_size_function() -> ScreenSizeGetter:
@@ -198,6 +274,49 @@ def set_cursor_for_place(place: 'Place', cmd: GraphicsCommand, width: int, heigh
     sys.stdout.buffer.write('\033[{};{}H'.format(place.top + 1, x + extra_cells).encode('ascii'))
 
 
+def placement(width: int, height: int, align: str, place: Optional['Place'] = None) -> Dict[str, int]:
+    ss = get_screen_size()
+    cw, ch = int(ss.width / ss.cols), int(ss.height / ss.rows)
+    left, top, cols = 0, 0, ss.cols
+    if place:
+        left, top, cols = place.left, place.top, place.width
+    num_of_cells_needed = int(ceil(width / cw))
+    x_offset = 0
+    if num_of_cells_needed > cols:
+        width, height = fit_image(width, height, cols * cw, height)
+        num_of_cells_needed = cols
+    else:
+        x_offset = calculate_in_cell_x_offset(width, cw, align)
+        if align == 'center':
+            left += (cols - num_of_cells_needed) // 2
+        elif align == 'right':
+            left += cols - num_of_cells_needed
+    return {
+        'cols': num_of_cells_needed, 'rows': int(ceil(height / ch)), 'left': left, 'top': top,
+        'x': left * cw + x_offset, 'y': top * ch, 'width': width, 'height': height
+    }
+
+
+def print_placement(p: Dict[str, int], fmt: str) -> None:
+    if fmt == 'json':
+        import json
+        print(json.dumps(p))
+    else:
+        print('cells: {cols}x{rows}@{left}x{top}'.format(**p))
+        print('pixels: {width}x{height}@{x}x{y}'.format(**p))
+
+
+def report_progress(sent: int, total: int, last_width: int) -> int:
+    # The progress report is written at the cursor position and the cursor is
+    # moved back to where it was so as to not change where the image is placed
+    text = '' if sent >= total else 'Sending image: {:.0%}'.format(sent / total)
+    width = max(len(text), last_width)
+    if width:
+        sys.stderr.write(text.ljust(width) + '\033[{}D'.format(width))
+        sys.stderr.flush()
+    return len(text)
+
+
 def write_chunked(cmd: GraphicsCommand, data: bytes) -> None:
     cmd = cmd.clone()
     if cmd.f != 100:
@@ -206,10 +325,20 @@ def write_chunked(cmd: GraphicsCommand, data: bytes) -> None:
     data = standard_b64encode(data)
     ac = cmd.a
     quiet = cmd.q
+    total = len(data)
+    progress = show_progress and total > PROGRESS_THRESHOLD
+    progress_width = last_percent = 0
     while data:
         chunk, data = data[:4096], data[4096:]
         cmd.m = 1 if data else 0
+        if progress and not data:
+            progress_width = report_progress(total, total, progress_width)
         write_gr_cmd(cmd, chunk)
+        if progress and data:
+            percent = 100 * (total - len(data)) // total
+            if percent != last_percent:
+                last_percent = percent
+                progress_width = report_progress(total - len(data), total, progress_width)
         cmd.clear()
         cmd.a = ac
         cmd.q = quiet
@@ -250,7 +379,17 @@ def show(
         write_chunked(cmd, data)
 
 
-def show_frames(frame_data: RenderedImage, use_number: int, loops: int) -> None:
+def frame_gap(gap: int, frame_delay: int = -1, speed: float = 1) -> int:
+    # A gap of -1 means a gapless frame in the graphics protocol, which is
+    # skipped, so keep overridden and sped up gaps at a minimum of 1ms
+    if frame_delay > -1:
+        return max(1, frame_delay)
+    if gap <= 0:
+        return -1
+    return max(1, int(gap / speed))
+
+
+def show_frames(frame_data: RenderedImage, use_number: int, loops: int, frame_delay: int = -1, speed: float = 1) -> None:
     transmit_cmd = GraphicsCommand()
     transmit_cmd.a = 'f'
     transmit_cmd.I = use_number  # noqa
@@ -279,7 +418,7 @@ def control(frame_number: int = 0, loops: Optional[int] = None, gap: Optional[in
         if frame.dispose < Dispose.previous:
             anchor_frame = frame_number
         if frame_number == 1:
-            control(frame_number, gap=frame.gap, loops=None if loops < 1 else loops)
+            control(frame_number, gap=frame_gap(frame.gap, frame_delay, speed), loops=None if loops < 1 else loops)
             continue
         if frame.dispose is Dispose.previous:
             if anchor_frame != frame_number:
@@ -290,7 +429,7 @@ def control(frame_number: int = 0, loops: Optional[int] = None, gap: Optional[in
         transmit_cmd.v = frame.height
         transmit_cmd.x = frame.canvas_x
         transmit_cmd.y = frame.canvas_y
-        transmit_cmd.z = frame.gap if frame.gap > 0 else -1
+        transmit_cmd.z = frame_gap(frame.gap, frame_delay, speed)
         if can_transfer_with_files:
             write_gr_cmd(transmit_cmd, standard_b64encode(os.path.abspath(frame.path).encode(fsenc)))
         else:
@@ -316,15 +455,50 @@ class ParsedOpts:
     z_index: int = 0
 
 
+def transformed_image(path: str, m: ImageData, args: IcatCLIOptions, trim_color: Optional[str]) -> str:
+    fd, output = mkstemp(prefix='icat-transformed-', suffix='.' + ('png' if len(m) == 1 else m.fmt))
+    os.close(fd)
+    try:
+        transform_image(
+            path, output, trim_color, args.trim_tolerance,
+            rotate=int(args.rotate), mirror=args.mirror, coalesce=len(m) > 1,
+            auto_orient=not args.no_exif_orientation)
+    except Exception:
+        os.remove(output)
+        raise
+    return output
+
+
 def process(path: str, args: IcatCLIOptions, parsed_opts: ParsedOpts, is_tempfile: bool) -> bool:
     m = identify(path)
+    trim_color: Optional[str] = None
+    if args.trim_borders != 'none' and len(m) == 1:
+        trim_color = '' if args.trim_borders == 'auto' else args.trim_borders
+    if trim_color is None and args.rotate == '0' and args.mirror == 'none':
+        return process_image(path, m, args, parsed_opts, is_tempfile)
+    transformed = transformed_image(path, m, args, trim_color)
+    file_removed = False
+    try:
+        file_removed = process_image(transformed, identify(transformed), args, parsed_opts, True)
+    finally:
+        if not file_removed:
+            os.remove(transformed)
+    return False
+
+
+def process_image(path: str, m: ImageData, args: IcatCLIOptions, parsed_opts: ParsedOpts, is_tempfile: bool) -> bool:
     ss = get_screen_size()
     available_width = parsed_opts.place.width * (ss.width // ss.cols) if parsed_opts.place else ss.width
     available_height = parsed_opts.place.height * (ss.height // ss.rows) if parsed_opts.place else 10 * m.height
     needs_scaling = m.width > available_width or m.height > available_height
     needs_scaling = needs_scaling or args.scale_up
+    if args.print_placement != 'none':
+        width, height = rendered_size(m, available_width, available_height, args.scale_up)[:2]
+        print_placement(placement(width, height, args.align, parsed_opts.place), args.print_placement)
+        return False
     file_removed = False
     use_number = 0
+    loops = 1 if args.no_loop else args.loop
     if m.fmt == 'png' and not needs_scaling:
         outfile = path
         transmit_mode: 'GRT_t' = 't' if is_tempfile else 'f'
@@ -334,21 +508,23 @@ def process(path: str, args: IcatCLIOptions, parsed_opts: ParsedOpts, is_tempfil
     else:
         fmt = 24 if m.mode == 'rgb' else 32
         transmit_mode = 't'
-        if len(m) == 1 or args.loop == 0:
-            outfile, width, height = render_as_single_image(path, m, available_width, available_height, args.scale_up)
+        if len(m) == 1 or loops == 0:
+            outfile, width, height = render_as_single_image(
+                path, m, available_width, available_height, args.scale_up, auto_orient=not args.no_exif_orientation)
         else:
             import struct
             use_number = max(1, struct.unpack('@I', os.urandom(4))[0])
             with NamedTemporaryFile() as f:
                 prefix = f.name
-            frame_data = render_image(path, prefix, m, available_width, available_height, args.scale_up)
+            frame_data = render_image(
+                path, prefix, m, available_width, available_height, args.scale_up, auto_orient=not args.no_exif_orientation)
             outfile, width, height = frame_data.frames[0].path, frame_data.width, frame_data.height
     show(
         outfile, width, height, parsed_opts.z_index, fmt, transmit_mode,
         align=args.align, place=parsed_opts.place, use_number=use_number
     )
     if use_number:
-        show_frames(frame_data, use_number, args.loop)
+        show_frames(frame_data, use_number, loops, args.frame_delay, args.speed)
         if not can_transfer_with_files:
             for fr in frame_data.frames:
                 with contextlib.suppress(FileNotFoundError):
@@ -492,7 +668,7 @@ def process_single_item(
 
 
 def main(args: List[str] = sys.argv) -> None:
-    global can_transfer_with_files
+    global can_transfer_with_files, show_progress
     cli_opts, items_ = parse_args(args[1:], options_spec, usage, help_text, '{} +kitten icat'.format(appname), result_class=IcatCLIOptions)
     items: List[Union[str, bytes]] = list(items_)
 
@@ -503,7 +679,7 @@ def main(args: List[str] = sys.argv) -> None:
         print('{}x{}'.format(ss.width, ss.height), end='')
         raise SystemExit(0)
 
-    if not sys.stdout.isatty():
+    if not sys.stdout.isatty() and cli_opts.print_placement == 'none':
         sys.stdout = open(os.ctermid(), 'w')
     stdin_data = None
     if cli_opts.stdin == 'yes' or (not sys.stdin.isatty() and cli_opts.stdin == 'detect'):
@@ -528,6 +704,9 @@ def main(args: List[str] = sys.argv) -> None:
         except Exception:
             raise SystemExit('Not a valid place specification: {}'.format(cli_opts.place))
 
+    if cli_opts.speed <= 0:
+        raise SystemExit('The value of --speed must be positive')
+
     try:
         parsed_opts.z_index = parse_z_index(cli_opts.z_index)
     except Exception:
@@ -538,11 +717,12 @@ def main(args: List[str] = sys.argv) -> None:
             raise SystemExit(1)
         print('file' if can_transfer_with_files else 'stream', end='', file=sys.stderr)
         return
-    if cli_opts.transfer_mode == 'detect':
+    if cli_opts.transfer_mode == 'detect' and cli_opts.print_placement == 'none':
         if not detect_support(wait_for=cli_opts.detection_timeout, silent=cli_opts.silent):
             raise SystemExit('This terminal emulator does not support the graphics protocol, use a terminal emulator such as kitty that does support it')
     else:
         can_transfer_with_files = cli_opts.transfer_mode == 'file'
+    show_progress = not cli_opts.silent and sys.stderr.isatty()
     errors = []
     if cli_opts.clear:
         sys.stdout.write(clear_images_on_screen(delete_data=True))
@@ -553,7 +733,8 @@ def main(args: List[str] = sys.argv) -> None:
     if parsed_opts.place:
         if len(items) > 1 or (isinstance(items[0], str) and os.path.isdir(items[0])):
             raise SystemExit(f'The --place option can only be used with a single image, not {items}')
-        sys.stdout.buffer.write(b'\0337')  # save cursor
+        if cli_opts.print_placement == 'none':
+            sys.stdout.buffer.write(b'\0337')  # save cursor
     url_pat = re.compile(r'(?:https?|ftp)://', flags=re.I)
     for item in items:
         try:
@@ -567,7 +748,7 @@ def main(args: List[str] = sys.argv) -> None:
             raise SystemExit(str(e))
         except OpenFailed as e:
             errors.append(e)
-    if parsed_opts.place:
+    if parsed_opts.place and cli_opts.print_placement == 'none':
         sys.stdout.buffer.write(b'\0338')  # restore cursor
     if errors:
         for err in errors:
@@ -58,7 +58,7 @@ def on_kitty_cmd_response(self, response: Dict[str, Any]) -> None:
             self.quit_loop(1)
             return
         res = response.get('data')
-        if res:
+        if res and (not isinstance(res, dict) or res.get('error')):
             self.cmd.bell()
 
     def on_text(self, text: str, in_bracketed_paste: bool = False) -> None:
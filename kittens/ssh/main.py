@@ -90,6 +90,27 @@ def get_ssh_cli() -> Tuple[Set[str], Set[str]]:
     return set('-' + x for x in boolean_ssh_args), set('-' + x for x in other_ssh_args)
 
 
+def parse_destination(destination: str, is_url: bool = False) -> Tuple[str, str, Optional[int]]:
+    # returns user, hostname and port, handling ssh:// URLs and bracketed
+    # IPv6 addresses, such as ssh://user@[2001:db8::1]:2222. ssh itself does
+    # not accept a port in the destination, outside URLs, so neither do we.
+    # Use is_url for the network location part of URLs.
+    port: Optional[int] = None
+    if destination.startswith('ssh://'):
+        destination = destination[len('ssh://'):].partition('/')[0]
+        is_url = True
+    user, sep, hostname = destination.rpartition('@')
+    if hostname.startswith('['):
+        hostname, sep, rest = hostname[1:].partition(']')
+        if is_url and rest.startswith(':') and rest[1:].isdigit():
+            port = int(rest[1:])
+    elif is_url and hostname.count(':') == 1:
+        q, sep, rest = hostname.partition(':')
+        if rest.isdigit():
+            hostname, port = q, int(rest)
+    return user, hostname, port
+
+
 def get_connection_data(args: List[str]) -> Optional[SSHConnectionData]:
     boolean_ssh_args, other_ssh_args = get_ssh_cli()
     found_ssh = ''
@@ -122,6 +143,8 @@ def get_connection_data(args: List[str]) -> Optional[SSHConnectionData]:
             expecting_option_val = False
             continue
 
+        if port is None:
+            port = parse_destination(arg)[2]
         return SSHConnectionData(found_ssh, arg, port)
 
 
@@ -188,21 +211,35 @@ def get_posix_cmd(terminfo: str, remote_args: List[str]) -> List[str]:
     return [sh_script] + remote_args
 
 
-def get_python_cmd(terminfo: str, command_to_execute: List[str]) -> List[str]:
+def get_python_cmd(terminfo: str, command_to_execute: List[str], interpreter: str = 'python') -> List[str]:
     import json
     script = PYTHON_SCRIPT.format(
         terminfo=terminfo.encode('utf-8').hex(),
         command_to_execute=json.dumps(command_to_execute).encode('utf-8').hex()
     )
-    return [f'python -c "{script}"']
+    return [f'{interpreter} -c "{script}"']
+
+
+def get_interpreter_cmd(terminfo: str, remote_args: List[str], interpreter: str) -> List[str]:
+    # Use the specified python interpreter if it exists on the remote
+    # machine, otherwise fall back to the POSIX shell script
+    q = shlex.quote(interpreter)
+    probe = f'command -v {q} >/dev/null 2>&1 && exec {get_python_cmd(terminfo, remote_args, q)[0]}\n'
+    warning = shlex.quote(f'kitty ssh: {interpreter} not found, falling back to sh')
+    posix = get_posix_cmd(terminfo, remote_args)
+    return [f'{probe}echo {warning} >&2\n{posix[0]}'] + posix[1:]
 
 
 def main(args: List[str]) -> NoReturn:
     args = args[1:]
     use_posix = True
+    interpreter = ''
     if args and args[0] == 'use-python':
         args = args[1:]
         use_posix = False
+    elif args and args[0].startswith('use-python='):
+        interpreter = args[0].partition('=')[2]
+        args = args[1:]
     ssh_args, server_args, passthrough = parse_ssh_args(args)
     cmd = ['ssh'] + ssh_args
     if passthrough:
@@ -211,8 +248,11 @@ def main(args: List[str]) -> NoReturn:
         hostname, remote_args = server_args[0], server_args[1:]
         cmd += ['-t', hostname]
         terminfo = subprocess.check_output(['infocmp']).decode('utf-8')
-        f = get_posix_cmd if use_posix else get_python_cmd
-        cmd += f(terminfo, remote_args)
+        if interpreter:
+            cmd += get_interpreter_cmd(terminfo, remote_args, interpreter)
+        else:
+            f = get_posix_cmd if use_posix else get_python_cmd
+            cmd += f(terminfo, remote_args)
     os.execvp('ssh', cmd)
 
 
@@ -170,33 +170,53 @@ def identify(path: str) -> ImageData:
     return ImageData(image_fmt, frames[0].canvas_width, frames[0].canvas_height, mode, frames)
 
 
+vector_formats = frozenset(('svg', 'msvg', 'mvg'))
+
+
 class RenderedImage(ImageData):
 
     def __init__(self, fmt: str, width: int, height: int, mode: str):
         super().__init__(fmt, width, height, mode, [])
 
 
-def render_image(
-    path: str, output_prefix: str,
-    m: ImageData,
-    available_width: int, available_height: int,
-    scale_up: bool,
-    only_first_frame: bool = False
-) -> RenderedImage:
-    import tempfile
-    has_multiple_frames = len(m) > 1
-    get_multiple_frames = has_multiple_frames and not only_first_frame
+def convert_cmd() -> List[str]:
     exe = find_exe('magick')
     if exe:
-        cmd = [exe, 'convert']
-    else:
-        exe = find_exe('convert')
-        if exe is None:
-            raise OSError('Failed to find the ImageMagick convert executable, make sure it is present in PATH')
-        cmd = [exe]
-    cmd += ['-background', 'none', '--', path]
-    if only_first_frame and has_multiple_frames:
-        cmd[-1] += '[0]'
+        return [exe, 'convert']
+    exe = find_exe('convert')
+    if exe is None:
+        raise OSError('Failed to find the ImageMagick convert executable, make sure it is present in PATH')
+    return [exe]
+
+
+def transform_image(
+    path: str, output: str,
+    trim_color: Optional[str] = None, tolerance: float = 10,
+    rotate: int = 0, mirror: str = 'none',
+    coalesce: bool = False, auto_orient: bool = True
+) -> None:
+    cmd = convert_cmd() + ['--', path]
+    if coalesce:
+        cmd.append('-coalesce')
+    if auto_orient:
+        cmd.append('-auto-orient')
+    if trim_color is not None:
+        if trim_color:
+            # surround the image with a one pixel border of the specified color so
+            # that trim uses it rather than the color of the corners
+            cmd += ['-bordercolor', trim_color, '-border', '1x1']
+        cmd += ['-fuzz', f'{tolerance}%', '-trim', '+repage']
+    if rotate:
+        cmd += ['-rotate', str(rotate), '+repage']
+    if mirror in ('horizontal', 'both'):
+        cmd.append('-flop')
+    if mirror in ('vertical', 'both'):
+        cmd.append('-flip')
+    cmd.append(output)
+    run_imagemagick(path, cmd, keep_stdout=False)
+
+
+def rendered_size(m: ImageData, available_width: int, available_height: int, scale_up: bool) -> Tuple[int, int, bool]:
     scaled = False
     width, height = m.width, m.height
     if scale_up:
@@ -206,12 +226,42 @@ def render_image(
             scaled = True
     if scaled or width > available_width or height > available_height:
         width, height = fit_image(width, height, available_width, available_height)
+        return width, height, True
+    return width, height, False
+
+
+def render_image(
+    path: str, output_prefix: str,
+    m: ImageData,
+    available_width: int, available_height: int,
+    scale_up: bool,
+    only_first_frame: bool = False,
+    auto_orient: bool = True
+) -> RenderedImage:
+    import tempfile
+    has_multiple_frames = len(m) > 1
+    get_multiple_frames = has_multiple_frames and not only_first_frame
+    cmd = convert_cmd()
+    width, height, needs_resize = rendered_size(m, available_width, available_height, scale_up)
+    resize_cmd: List[str] = []
+    if needs_resize:
         resize_cmd = ['-resize', '{}x{}!'.format(width, height)]
         if get_multiple_frames:
             # we have to coalesce, resize and de-coalesce all frames
             resize_cmd = ['-coalesce'] + resize_cmd + ['-deconstruct']
-        cmd += resize_cmd
-    cmd += ['-depth', '8', '-auto-orient', '-set', 'filename:f', '%w-%h-%g-%p']
+        if m.fmt in vector_formats:
+            # rasterize vector images at the final size rather than scaling
+            # the bitmap rendered at the default density, for crisp output
+            density = (m.frames[0].xdpi or 72) * width / m.width
+            cmd += ['-density', '{:.4f}'.format(density)]
+    cmd += ['-background', 'none', '--', path]
+    if only_first_frame and has_multiple_frames:
+        cmd[-1] += '[0]'
+    cmd += resize_cmd
+    cmd += ['-depth', '8']
+    if auto_orient:
+        cmd.append('-auto-orient')
+    cmd += ['-set', 'filename:f', '%w-%h-%g-%p']
     ans = RenderedImage(m.fmt, width, height, m.mode)
     if only_first_frame:
         ans.frames = [Frame(m.frames[0])]
@@ -278,12 +328,13 @@ def render_as_single_image(
     path: str, m: ImageData,
     available_width: int, available_height: int,
     scale_up: bool,
-    tdir: Optional[str] = None
+    tdir: Optional[str] = None,
+    auto_orient: bool = True
 ) -> Tuple[str, int, int]:
     import tempfile
     fd, output = tempfile.mkstemp(prefix='icat-', suffix=f'.{m.mode}', dir=tdir)
     os.close(fd)
-    result = render_image(path, output, m, available_width, available_height, scale_up, only_first_frame=True)
+    result = render_image(path, output, m, available_width, available_height, scale_up, only_first_frame=True, auto_orient=auto_orient)
     os.rename(result.frames[0].path, output)
     return output, result.width, result.height
 
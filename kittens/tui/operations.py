@@ -22,6 +22,8 @@
 RESTORE_PRIVATE_MODE_VALUES = '\033[?r'
 SAVE_COLORS = '\033[#P'
 RESTORE_COLORS = '\033[#Q'
+# the number of bytes of data sent in each OSC 52 escape code
+CLIPBOARD_CHUNK_SIZE = 512
 MODES = dict(
     LNM=(20, ''),
     IRM=(4, ''),
@@ -361,7 +363,7 @@ def esc(chunk: str) -> str:
         return '\x1b]52;{};{}\x07'.format(fmt, chunk)
 
     ans = esc('!')  # clear clipboard buffer
-    for chunk in (data[i:i+512] for i in range(0, len(data), 512)):
+    for chunk in (data[i:i+CLIPBOARD_CHUNK_SIZE] for i in range(0, len(data), CLIPBOARD_CHUNK_SIZE)):
         s = standard_b64encode(chunk).decode('ascii')
         ans += esc(s)
     return ans
@@ -6,7 +6,7 @@
 import subprocess
 import sys
 from contextlib import suppress
-from functools import lru_cache
+from functools import lru_cache, partial
 from gettext import gettext as _
 from typing import (
     Any, Dict, FrozenSet, Generator, Iterable, List, Optional, Sequence, Tuple,
@@ -266,8 +266,11 @@ def is_index(w: str) -> bool:
 
 class UnicodeInput(Handler):
 
-    def __init__(self, cached_values: Dict[str, Any], emoji_variation: str = 'none') -> None:
+    def __init__(self, cached_values: Dict[str, Any], emoji_variation: str = 'none', multiple: bool = False) -> None:
         self.cached_values = cached_values
+        self.multiple = multiple
+        self.chosen: List[str] = []
+        self.chosen_codepoints: List[int] = []
         self.emoji_variation = ''
         if emoji_variation == 'text':
             self.emoji_variation = '\ufe0e'
@@ -396,6 +399,8 @@ def writeln(text: str = '') -> None:
         with cursor(self.write):
             writeln()
             writeln(self.choice_line)
+            if self.multiple:
+                writeln(_('Chosen: {}').format(''.join(self.chosen)) + faint(_(' (Enter to add, Esc to finish)')))
             if self.mode is HEX:
                 writeln(faint(_('Type {} followed by the index for the recent entries below').format(INDEX_CHAR)))
             elif self.mode is NAME:
@@ -464,10 +469,13 @@ def on_key(self, key_event: KeyEvent) -> None:
             self.refresh()
             return
         if key_event.matches('enter'):
+            if self.multiple:
+                self.add_current_char()
+                return
             self.quit_loop(0)
             return
         if key_event.matches('esc'):
-            self.quit_loop(1)
+            self.quit_loop(0 if self.chosen else 1)
             return
         if key_event.matches('f1'):
             self.switch_mode(HEX)
@@ -492,6 +500,16 @@ def on_key(self, key_event: KeyEvent) -> None:
                 self.next_mode(-1 if key == '[' else 1)
                 return
 
+    def add_current_char(self) -> None:
+        ans = self.resolved_current_char
+        if self.current_char and ans:
+            self.chosen.append(ans)
+            self.chosen_codepoints.append(ord(self.current_char))
+            self.line_edit.clear()
+            self.refresh()
+        else:
+            self.cmd.bell()
+
     def edit_favorites(self) -> None:
         if not os.path.exists(favorites_path):
             with open(favorites_path, 'wb') as f:
@@ -529,7 +547,7 @@ def on_resize(self, new_size: ScreenSize) -> None:
 
 help_text = 'Input a unicode character'
 usage = ''
-OPTIONS = '''
+OPTIONS = partial('''
 --emoji-variation
 type=choices
 default=none
@@ -538,11 +556,26 @@ def on_resize(self, new_size: ScreenSize) -> None:
 default form specified in the unicode standard for the symbol is used.
 
 
-'''.format
+--max-recent
+type=int
+default={max_recent}
+The maximum number of recently used characters to remember. These are shown
+in the :italic:`Code` mode of the kitten. Must be a number from 1 to 4096.
+
+
+--multiple
+type=bool-set
+Choose multiple characters, one after the other. In this mode, pressing
+:kbd:`Enter` adds the current character to the chosen characters and
+pressing :kbd:`Esc` finishes, inputting all the chosen characters.
+'''.format, max_recent=len(DEFAULT_SET))
 
 
 def parse_unicode_input_args(args: List[str]) -> Tuple[UnicodeCLIOptions, List[str]]:
-    return parse_args(args, OPTIONS, usage, help_text, 'kitty +kitten unicode_input', result_class=UnicodeCLIOptions)
+    opts, items = parse_args(args, OPTIONS, usage, help_text, 'kitty +kitten unicode_input', result_class=UnicodeCLIOptions)
+    if not 0 < opts.max_recent <= 4096:
+        raise SystemExit('The value of --max-recent must be a number from 1 to 4096, not: {}'.format(opts.max_recent))
+    return opts, items
 
 
 def main(args: List[str]) -> Optional[str]:
@@ -556,14 +589,23 @@ def main(args: List[str]) -> Optional[str]:
 
     loop = Loop()
     with cached_values_for('unicode-input') as cached_values:
-        handler = UnicodeInput(cached_values, cli_opts.emoji_variation)
+        handler = UnicodeInput(cached_values, cli_opts.emoji_variation, cli_opts.multiple)
         loop.loop(handler)
-        if handler.current_char and loop.return_code == 0:
-            with suppress(Exception):
-                handler.recent.remove(ord(handler.current_char))
-            recent = [ord(handler.current_char)] + handler.recent
-            cached_values['recent'] = recent[:len(DEFAULT_SET)]
-            return handler.resolved_current_char
+        if loop.return_code == 0:
+            if cli_opts.multiple:
+                codepoints, ans = handler.chosen_codepoints, ''.join(handler.chosen)
+            elif handler.current_char:
+                codepoints, ans = [ord(handler.current_char)], handler.resolved_current_char or ''
+            else:
+                codepoints, ans = [], ''
+            if codepoints:
+                recent = handler.recent
+                for cp in codepoints:
+                    with suppress(ValueError):
+                        recent.remove(cp)
+                    recent.insert(0, cp)
+                cached_values['recent'] = recent[:cli_opts.max_recent]
+                return ans
     if loop.return_code != 0:
         raise SystemExit(loop.return_code)
     return None
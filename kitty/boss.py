@@ -242,6 +242,9 @@ def all_windows(self) -> Generator[Window, None, None]:
         for tab in self.all_tabs:
             yield from tab
 
+    def windows_by_recent_focus(self) -> List[Window]:
+        return sorted((w for w in self.all_windows if w.last_focused_at), key=lambda w: w.last_focused_at, reverse=True)
+
     def match_windows(self, match: str) -> Generator[Window, None, None]:
         try:
             field, exp = match.split(':', 1)
@@ -257,7 +260,7 @@ def match_windows(self, match: str) -> Generator[Window, None, None]:
                 if w is not None:
                     yield w
             return
-        if field != 'env':
+        if field not in ('env', 'user'):
             pat: MatchPatternType = re.compile(exp)
         else:
             kp, vp = exp.partition('=')[::2]
@@ -352,7 +355,8 @@ def _handle_remote_command(self, cmd: str, window: Optional[Window] = None, from
                 response = handle_cmd(self, window, cmd)
             except Exception as err:
                 import traceback
-                response = {'ok': False, 'error': str(err)}
+                from .rc.base import error_code_for
+                response = {'ok': False, 'error': str(err), 'error_code': error_code_for(err)}
                 if not getattr(err, 'hide_traceback', False):
                     response['tb'] = traceback.format_exc()
         else:
@@ -362,7 +366,9 @@ def _handle_remote_command(self, cmd: str, window: Optional[Window] = None, from
             except Exception:
                 pass
             if not no_response:
-                response = {'ok': False, 'error': 'Remote control is disabled. Add allow_remote_control to your kitty.conf'}
+                response = {
+                    'ok': False, 'error': 'Remote control is disabled. Add allow_remote_control to your kitty.conf',
+                    'error_code': 'permission_denied'}
         return response
 
     def remote_control(self, *args: str) -> None:
@@ -573,7 +579,10 @@ def restore_font_size(self) -> None:  # legacy
     def set_font_size(self, new_size: float) -> None:  # legacy
         self.change_font_size(True, None, new_size)
 
-    def change_font_size(self, all_windows: bool, increment_operation: Optional[str], amt: float) -> None:
+    def change_font_size(
+        self, all_windows: bool, increment_operation: Optional[str], amt: float,
+        os_window_ids: Optional[Iterable[int]] = None
+    ) -> Dict[int, float]:
         def calc_new_size(old_size: float) -> float:
             new_size = old_size
             if amt == 0:
@@ -592,21 +601,26 @@ def calc_new_size(old_size: float) -> float:
             if new_size != current_global_size:
                 global_font_size(new_size)
             os_windows = list(self.os_window_map.keys())
+        elif os_window_ids is not None:
+            os_windows = list(os_window_ids)
         else:
             os_windows = []
             w = self.active_window
             if w is not None:
                 os_windows.append(w.os_window_id)
+        sizes = {}
         if os_windows:
             final_windows = {}
             for wid in os_windows:
                 current_size = os_window_font_size(wid)
                 if current_size:
                     new_size = calc_new_size(current_size)
+                    sizes[wid] = new_size
                     if new_size != current_size:
                         final_windows[wid] = new_size
             if final_windows:
                 self._change_font_size(final_windows)
+        return sizes
 
     def _change_font_size(self, sz_map: Dict[int, float]) -> None:
         for os_window_id, sz in sz_map.items():
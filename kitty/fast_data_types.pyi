@@ -714,6 +714,10 @@ def background_opacity_of(os_window_id: int) -> Optional[float]:
     pass
 
 
+def os_window_is_semi_transparent(os_window_id: int) -> bool:
+    pass
+
+
 def read_command_response(fd: int, timeout: float, list: List) -> None:
     pass
 
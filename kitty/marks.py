@@ -4,7 +4,7 @@
 
 import os
 import re
-from ctypes import POINTER, c_uint, c_void_p, cast
+from ctypes import POINTER, addressof, c_uint, c_void_p, cast
 from typing import Callable, Generator, Iterable, Pattern, Tuple, Union, Sequence
 
 from .constants import config_dir
@@ -23,6 +23,12 @@ def get_output_variables(left_address: int, right_address: int, color_address: i
     )
 
 
+def count_marks(marker: MarkerFunc, lines: Iterable[str]) -> int:
+    left, right, color = c_uint(), c_uint(), c_uint()
+    addresses = addressof(left), addressof(right), addressof(color)
+    return sum(1 for line in lines for _ in marker(line, *addresses) if color.value)
+
+
 def marker_from_regex(expression: Union[str, Pattern], color: int, flags: int = re.UNICODE) -> MarkerFunc:
     color = max(1, min(color, 3))
     if isinstance(expression, str):
@@ -29,12 +29,14 @@ class NoResponse:
 
 
 class RemoteControlError(Exception):
-    pass
+
+    error_code = 'invalid_argument'
 
 
 class MatchError(ValueError):
 
     hide_traceback = True
+    error_code = 'no_match'
 
     def __init__(self, expression: str, target: str = 'windows'):
         ValueError.__init__(self, 'No matching {} for expression: {}'.format(target, expression))
@@ -43,11 +45,20 @@ def __init__(self, expression: str, target: str = 'windows'):
 class OpacityError(ValueError):
 
     hide_traceback = True
+    error_code = 'not_enabled'
 
 
 class UnknownLayout(ValueError):
 
     hide_traceback = True
+    error_code = 'unknown_layout'
+
+
+def error_code_for(err: Exception) -> str:
+    ans = getattr(err, 'error_code', None)
+    if ans is None:
+        ans = 'invalid_argument' if isinstance(err, (ValueError, TypeError)) else 'unknown'
+    return str(ans)
 
 
 class PayloadGetter:
@@ -76,7 +87,7 @@ def __call__(self, key: str, opt_name: Optional[str] = None, missing: Any = None
 MATCH_WINDOW_OPTION = '''\
 --match -m
 The window to match. Match specifications are of the form:
-:italic:`field:regexp`. Where field can be one of: id, title, pid, cwd, cmdline, num, env.
+:italic:`field:regexp`. Where field can be one of: id, title, pid, cwd, cmdline, num, env, user.
 You can use the :italic:`ls` command to get a list of windows. Note that for
 numeric fields such as id, pid and num the expression is interpreted as a number,
 not a regular expression. The field num refers to the window position in the current tab,
@@ -84,13 +95,15 @@ def __call__(self, key: str, opt_name: Optional[str] = None, missing: Any = None
 windows are reported by the :italic:`ls` command). The window id of the current window
 is available as the KITTY_WINDOW_ID environment variable. When using the :italic:`env` field
 to match on environment variables you can specify only the environment variable name or a name
-and value, for example, :italic:`env:MY_ENV_VAR=2`
+and value, for example, :italic:`env:MY_ENV_VAR=2`. Similarly, the :italic:`user` field
+matches user variables set with the :italic:`set-user-var` command, for example,
+:italic:`user:project=kitty`
 '''
 MATCH_TAB_OPTION = '''\
 --match -m
 The tab to match. Match specifications are of the form:
 :italic:`field:regexp`. Where field can be one of:
-id, title, window_id, window_title, pid, cwd, env, cmdline.
+id, title, window_id, window_title, pid, cwd, env, user, cmdline.
 You can use the :italic:`ls` command to get a list of tabs. Note that for
 numeric fields such as id and pid the expression is interpreted as a number,
 not a regular expression. When using title or id, first a matching tab is
@@ -188,6 +201,9 @@ def message_to_kitty(self, global_opts: RCOptions, opts: Any, args: ArgsType) ->
     def response_from_kitty(self, boss: 'Boss', window: Optional['Window'], payload_get: PayloadGetType) -> ResponseType:
         raise NotImplementedError()
 
+    def response_for_cli(self, data: Any, response_time: float) -> Any:
+        return data
+
 
 def cli_params_for(command: RemoteCommand) -> Tuple[Callable[[], str], str, str, str]:
     return (command.options_spec or '\n').format, command.argspec, command.desc, '{} @ {}'.format(appname, command.name)
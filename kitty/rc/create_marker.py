@@ -27,6 +27,8 @@ class CreateMarker(RemoteCommand):
     desc = (
         'Create a marker which can highlight text in the specified window. For example: '
         'create_marker text 1 ERROR. For full details see: https://sw.kovidgoyal.net/kitty/marks.html'
+        ' The number of matches of the marker in the text of the windows, including their'
+        ' scrollback, is printed.'
     )
     options_spec = MATCH_WINDOW_OPTION + '''\n
 --self
@@ -43,8 +45,11 @@ def message_to_kitty(self, global_opts: RCOptions, opts: 'CLIOptions', args: Arg
 
     def response_from_kitty(self, boss: Boss, window: Optional[Window], payload_get: PayloadGetType) -> ResponseType:
         args = payload_get('marker_spec')
+        count = 0
         for window in self.windows_for_match_payload(boss, window, payload_get):
             window.set_marker(args)
+            count += window.count_marks()
+        return str(count)
 
 
 create_marker = CreateMarker()
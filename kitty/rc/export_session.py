@@ -0,0 +1,80 @@
+#!/usr/bin/env python
+# vim:fileencoding=utf-8
+# License: GPLv3 Copyright: 2021, Kovid Goyal <kovid at kovidgoyal.net>
+
+import shlex
+from typing import TYPE_CHECKING, List, Optional
+
+from .base import (
+    ArgsType, Boss, PayloadGetType, PayloadType, RCOptions, RemoteCommand,
+    ResponseType, Window
+)
+
+if TYPE_CHECKING:
+    from kitty.cli_stub import ExportSessionRCOptions as CLIOptions
+
+
+def quoted(cmd: List[str]) -> str:
+    return ' '.join(map(shlex.quote, cmd))
+
+
+class ExportSession(RemoteCommand):
+    '''
+    no_cwd: Boolean indicating whether to omit the working directory of windows
+    no_title: Boolean indicating whether to omit the titles of windows
+    '''
+
+    short_desc = 'Export the current tabs and windows as a session file'
+    desc = (
+        'Print the current OS windows, tabs and windows, along with their layouts, working directories'
+        ' and titles, in the format used by session files. The output can be used with the'
+        ' :option:`kitty --session` command line flag or with :ref:`at_import-session` to recreate'
+        ' the current setup.'
+    )
+    options_spec = '''\
+--no-cwd
+type=bool-set
+Do not record the working directory of windows.
+
+
+--no-title
+type=bool-set
+Do not record the titles of tabs and windows.
+'''
+    argspec = ''
+
+    def message_to_kitty(self, global_opts: RCOptions, opts: 'CLIOptions', args: ArgsType) -> PayloadType:
+        return {'no_cwd': opts.no_cwd, 'no_title': opts.no_title}
+
+    def response_from_kitty(self, boss: Boss, window: Optional[Window], payload_get: PayloadGetType) -> ResponseType:
+        no_cwd, no_title = payload_get('no_cwd'), payload_get('no_title')
+        lines: List[str] = []
+        for i, tm in enumerate(boss.all_tab_managers):
+            if i:
+                lines.append('')
+                lines.append('new_os_window')
+            active_tab = tm.active_tab
+            for j, tab in enumerate(tm):
+                if j:
+                    lines.append('')
+                lines.append('new_tab' + ('' if no_title or not tab.name else ' ' + tab.name))
+                lines.append('enabled_layouts ' + ','.join(tab.enabled_layouts))
+                lines.append('layout ' + tab.current_layout.name)
+                active_window = tab.active_window
+                for w in tab:
+                    cmd: List[str] = []
+                    if not no_cwd:
+                        cwd = w.child.current_cwd or w.child.cwd
+                        if cwd:
+                            cmd.append('--cwd=' + cwd)
+                    if not no_title and w.override_title:
+                        cmd.append('--title=' + w.override_title)
+                    cmd.append('--')
+                    cmd.extend(w.child.argv)
+                    lines.append('launch ' + quoted(cmd))
+                    if tab is active_tab and w is active_window:
+                        lines.append('focus')
+        return '\n'.join(lines)
+
+
+export_session = ExportSession()
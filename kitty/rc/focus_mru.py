@@ -0,0 +1,53 @@
+#!/usr/bin/env python
+# vim:fileencoding=utf-8
+# License: GPLv3 Copyright: 2021, Kovid Goyal <kovid at kovidgoyal.net>
+
+from typing import TYPE_CHECKING, Optional
+
+from kitty.fast_data_types import focus_os_window
+
+from .base import (
+    ArgsType, Boss, PayloadGetType, PayloadType, RCOptions, RemoteCommand,
+    ResponseType, Window
+)
+
+if TYPE_CHECKING:
+    from kitty.cli_stub import FocusMRURCOptions as CLIOptions
+
+
+class FocusMRU(RemoteCommand):
+
+    '''
+    index: The position of the window to focus in the most recently used list
+    '''
+
+    short_desc = 'Focus a recently used window'
+    desc = (
+        'Focus the window at the specified position in the list of most recently'
+        ' used windows, as reported by :ref:`at_mru`. Position zero is the currently'
+        ' focused window, one is the previously focused window and so on.'
+    )
+    options_spec = '''\
+--index -i
+type=int
+default=1
+The position of the window to focus in the most recently used list.
+'''
+    string_return_is_error = True
+
+    def message_to_kitty(self, global_opts: RCOptions, opts: 'CLIOptions', args: ArgsType) -> PayloadType:
+        if opts.index < 0:
+            self.fatal('The index must not be negative')
+        return {'index': opts.index}
+
+    def response_from_kitty(self, boss: Boss, window: Optional[Window], payload_get: PayloadGetType) -> ResponseType:
+        windows = boss.windows_by_recent_focus()
+        idx = payload_get('index')
+        if idx >= len(windows):
+            return f'There is no window at position {idx} in the most recently used list'
+        os_window_id = boss.set_active_window(windows[idx])
+        if os_window_id:
+            focus_os_window(os_window_id, True)
+
+
+focus_mru = FocusMRU()
@@ -2,7 +2,7 @@
 # vim:fileencoding=utf-8
 # License: GPLv3 Copyright: 2020, Kovid Goyal <kovid at kovidgoyal.net>
 
-
+import re
 from typing import TYPE_CHECKING, Optional
 
 from .base import (
@@ -14,12 +14,21 @@
     from kitty.cli_stub import GetTextRCOptions as CLIOptions
 
 
+def last_lines(text: str, num: int) -> str:
+    lines = text.splitlines(keepends=True)
+    # ignore the empty lines at the bottom of the screen
+    while lines and not re.sub(r'\x1b\[[0-9;:]*m', '', lines[-1]).strip():
+        lines.pop()
+    return ''.join(lines[-num:])
+
+
 class GetText(RemoteCommand):
 
     '''
     match: The tab to focus
     extent: One of :code:`screen`, :code:`all`, or :code:`selection`
     ansi: Boolean, if True send ANSI formatting codes
+    lines: Integer, if positive only the last lines number of lines are sent
     self: Boolean, if True use window command was run in
     '''
 
@@ -39,6 +48,14 @@ class GetText(RemoteCommand):
 getting the current selection, the result is always plain text.
 
 
+--lines
+type=int
+default=0
+Only get the specified number of lines from the end of the text, ignoring empty
+lines at the bottom of the screen. Lines that are wrapped on the screen count as a
+single line. Zero means get all the text.
+
+
 --self
 type=bool-set
 If specified get text from the window this command is run in, rather than the active window.
@@ -46,7 +63,9 @@ class GetText(RemoteCommand):
     argspec = ''
 
     def message_to_kitty(self, global_opts: RCOptions, opts: 'CLIOptions', args: ArgsType) -> PayloadType:
-        return {'match': opts.match, 'extent': opts.extent, 'ansi': opts.ansi, 'self': opts.self}
+        if opts.lines < 0:
+            self.fatal('The number of lines must not be negative')
+        return {'match': opts.match, 'extent': opts.extent, 'ansi': opts.ansi, 'self': opts.self, 'lines': opts.lines}
 
     def response_from_kitty(self, boss: Boss, window: Optional[Window], payload_get: PayloadGetType) -> ResponseType:
         window = self.windows_for_match_payload(boss, window, payload_get)[0]
@@ -54,6 +73,8 @@ def response_from_kitty(self, boss: Boss, window: Optional[Window], payload_get:
             ans = window.text_for_selection()
         else:
             ans = window.as_text(as_ansi=bool(payload_get('ansi')), add_history=payload_get('extent') == 'all')
+        if payload_get('lines') and ans:
+            ans = last_lines(ans, payload_get('lines'))
         return ans
 
 
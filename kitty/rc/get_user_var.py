@@ -0,0 +1,51 @@
+#!/usr/bin/env python
+# vim:fileencoding=utf-8
+# License: GPLv3 Copyright: 2021, Kovid Goyal <kovid at kovidgoyal.net>
+
+from typing import TYPE_CHECKING, Optional
+
+from .base import (
+    MATCH_WINDOW_OPTION, ArgsType, Boss, MatchError, PayloadGetType,
+    PayloadType, RCOptions, RemoteCommand, ResponseType, Window
+)
+
+if TYPE_CHECKING:
+    from kitty.cli_stub import GetUserVarRCOptions as CLIOptions
+
+
+class GetUserVar(RemoteCommand):
+
+    '''
+    key+: The name of the variable
+    match: The window to get the variable from
+    '''
+
+    short_desc = 'Get the value of a user variable'
+    desc = (
+        'Get the value of a variable set with :ref:`at_set-user-var` on the specified window.'
+        ' If you use the :option:`kitty @ get-user-var --match` option the value from the first'
+        ' matched window is returned. By default, the window in which the command is run is used.'
+        ' Nothing is output if the variable is not set.'
+    )
+    options_spec = MATCH_WINDOW_OPTION
+    argspec = 'KEY'
+    args_count = 1
+
+    def message_to_kitty(self, global_opts: RCOptions, opts: 'CLIOptions', args: ArgsType) -> PayloadType:
+        return {'match': opts.match, 'key': args[0]}
+
+    def response_from_kitty(self, boss: Boss, window: Optional[Window], payload_get: PayloadGetType) -> ResponseType:
+        match = payload_get('match')
+        if match:
+            windows = tuple(boss.match_windows(match))
+            if not windows:
+                raise MatchError(match)
+            window = windows[0]
+        else:
+            window = window or boss.active_window
+        if window:
+            return window.user_vars.get(payload_get('key'))
+        return None
+
+
+get_user_var = GetUserVar()
@@ -0,0 +1,50 @@
+#!/usr/bin/env python
+# vim:fileencoding=utf-8
+# License: GPLv3 Copyright: 2021, Kovid Goyal <kovid at kovidgoyal.net>
+
+import sys
+from typing import Any, Optional
+
+from .base import (
+    ArgsType, Boss, PayloadGetType, PayloadType, RCOptions, RemoteCommand,
+    ResponseType, Window
+)
+
+
+class ImportSession(RemoteCommand):
+    '''
+    data+: The contents of the session file
+    '''
+
+    short_desc = 'Create OS windows, tabs and windows from a session file'
+    desc = (
+        'Read the specified session file and create the OS windows, tabs and windows it describes,'
+        ' in new OS windows. Use the special value - to read the session from STDIN.'
+        ' Session files can be created with :ref:`at_export-session`.'
+    )
+    argspec = 'PATH_TO_SESSION_FILE'
+    args_count = 1
+    args_completion = {'files': ('Session files', ('*.session', '*.kitty-session'))}
+
+    def message_to_kitty(self, global_opts: RCOptions, opts: Any, args: ArgsType) -> PayloadType:
+        if not args:
+            self.fatal('Must specify path to session file')
+        path = args[0]
+        try:
+            if path == '-':
+                data = sys.stdin.read()
+            else:
+                with open(path) as f:
+                    data = f.read()
+        except OSError as err:
+            self.fatal('Failed to read session file {} with error: {}'.format(path, err))
+        return {'data': data}
+
+    def response_from_kitty(self, boss: Boss, window: Optional[Window], payload_get: PayloadGetType) -> ResponseType:
+        from kitty.session import parse_session
+        for session in parse_session(payload_get('data'), boss.opts):
+            boss.add_os_window(session)
+        return None
+
+
+import_session = ImportSession()
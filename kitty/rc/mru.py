@@ -0,0 +1,39 @@
+#!/usr/bin/env python
+# vim:fileencoding=utf-8
+# License: GPLv3 Copyright: 2021, Kovid Goyal <kovid at kovidgoyal.net>
+
+import json
+from typing import Any, Optional
+
+from .base import (
+    ArgsType, Boss, PayloadGetType, PayloadType, RCOptions, RemoteCommand,
+    ResponseType, Window
+)
+
+
+class MRU(RemoteCommand):
+
+    '''
+    No payload
+    '''
+
+    short_desc = 'List windows in most recently used order'
+    desc = (
+        'List all windows that have had the keyboard focus, most recently focused first.'
+        ' The output is in JSON format, with the id, title and time of last focus'
+        ' (in seconds since the epoch) of each window. Windows can be focused by their'
+        ' position in this list with :ref:`at_focus-mru`.'
+    )
+
+    def message_to_kitty(self, global_opts: RCOptions, opts: Any, args: ArgsType) -> PayloadType:
+        pass
+
+    def response_from_kitty(self, boss: Boss, window: Optional[Window], payload_get: PayloadGetType) -> ResponseType:
+        data = [
+            {'id': w.id, 'title': w.title, 'last_focused_at': w.last_focused_at}
+            for w in boss.windows_by_recent_focus()
+        ]
+        return json.dumps(data, indent=2)
+
+
+mru = MRU()
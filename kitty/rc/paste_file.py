@@ -0,0 +1,114 @@
+#!/usr/bin/env python
+# vim:fileencoding=utf-8
+# License: GPLv3 Copyright: 2021, Kovid Goyal <kovid at kovidgoyal.net>
+
+import base64
+import sys
+from typing import TYPE_CHECKING, Dict, Generator, List, Optional, Set
+
+from .base import (
+    MATCH_WINDOW_OPTION, ArgsType, Boss, MatchError, PayloadGetType,
+    PayloadType, RCOptions, RemoteCommand, ResponseType, Window
+)
+
+if TYPE_CHECKING:
+    from kitty.cli_stub import PasteFileRCOptions as CLIOptions
+
+
+# ids of windows into which a bracketed paste is in progress. Whether to
+# bracket is decided on the first chunk, so that the paste is always closed,
+# even if the program changes the bracketed paste mode in the middle.
+bracketed_pastes: Set[int] = set()
+
+
+class PasteFile(RemoteCommand):
+    '''
+    data+: Chunk of the file contents as standard base64 encoded bytes
+    first: A boolean indicating this is the first chunk
+    last: A boolean indicating this is the last chunk
+    match: A string indicating the window to paste into
+    bracketed: A boolean indicating that the text should be wrapped in bracketed paste markers
+    '''
+    short_desc = 'Paste the contents of a file into the specified window'
+    desc = (
+        'Read the specified file and send its contents to the specified window as pasted text.'
+        ' If the program running in the window has turned on bracketed paste mode, the text is'
+        ' wrapped in bracketed paste markers so that it is treated as a paste, rather than typed input.'
+        ' Use - as the path to read from :italic:`stdin`.'
+        ' By default, text is pasted into only the currently active window.'
+    )
+    options_spec = MATCH_WINDOW_OPTION + '''\n
+--no-bracketed
+type=bool-set
+Send the file contents as is, without bracketed paste markers, like
+:ref:`at_send-text` does.
+'''
+    no_response = True
+    argspec = 'PATH'
+
+    def message_to_kitty(self, global_opts: RCOptions, opts: 'CLIOptions', args: ArgsType) -> PayloadType:
+        if len(args) != 1:
+            self.fatal('Must specify exactly one file to paste')
+        limit = 1024
+        ret = {'match': opts.match, 'bracketed': not opts.no_bracketed, 'first': True, 'last': False, 'data': ''}
+        path = args[0]
+        try:
+            f = sys.stdin.buffer if path == '-' else open(path, 'rb')
+        except OSError as err:
+            self.fatal(f'Failed to open {path} with error: {err}')
+
+        def file_pipe() -> Generator[Dict, None, None]:
+            pending = b''
+            with f:
+                while True:
+                    data = f.read(limit)
+                    if not data:
+                        break
+                    data = pending + data
+                    # do not split escape codes or CRLF across chunks so that paste
+                    # end markers in the file are always removed
+                    start = max(0, len(data) - 5)
+                    idx = max(data.rfind(b'\033', start), data.rfind(b'\x9b', start))
+                    if idx < 0 and data.endswith(b'\r'):
+                        idx = len(data) - 1
+                    if idx > -1:
+                        data, pending = data[:idx], data[idx:]
+                    else:
+                        pending = b''
+                    ret['data'] = base64.standard_b64encode(data).decode('ascii')
+                    yield ret
+                    ret['first'] = False
+            ret['data'] = base64.standard_b64encode(pending).decode('ascii')
+            ret['last'] = True
+            yield ret
+
+        return file_pipe()
+
+    def response_from_kitty(self, boss: Boss, window: Optional[Window], payload_get: PayloadGetType) -> ResponseType:
+        windows: List[Optional[Window]] = [boss.active_window]
+        match = payload_get('match')
+        if match:
+            windows = list(boss.match_windows(match))
+            if not windows:
+                raise MatchError(match)
+        data = base64.standard_b64decode(payload_get('data'))
+        for window in windows:
+            if window is None or window.destroyed:
+                continue
+            text = data
+            if payload_get('bracketed'):
+                start = b''
+                if payload_get('first'):
+                    bracketed_pastes.discard(window.id)
+                    if window.screen.in_bracketed_paste_mode:
+                        bracketed_pastes.add(window.id)
+                        start = b'\033[200~'
+                bracketed = window.id in bracketed_pastes
+                text = start + window.sanitize_for_paste(text, bracketed)
+                if bracketed and payload_get('last'):
+                    bracketed_pastes.discard(window.id)
+                    text += b'\033[201~'
+            window.write_to_child(text)
+
+
+paste_file = PasteFile()
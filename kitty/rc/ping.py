@@ -0,0 +1,47 @@
+#!/usr/bin/env python
+# vim:fileencoding=utf-8
+# License: GPLv3 Copyright: 2021, Kovid Goyal <kovid at kovidgoyal.net>
+
+
+from typing import TYPE_CHECKING, Any, Optional
+
+from .base import (
+    ArgsType, Boss, PayloadGetType, PayloadType, RCOptions, RemoteCommand,
+    ResponseType, Window
+)
+
+if TYPE_CHECKING:
+    from kitty.cli_stub import PingRCOptions as CLIOptions
+
+
+class Ping(RemoteCommand):
+    '''
+    No payload
+    '''
+
+    short_desc = 'Check if kitty is responding'
+    desc = (
+        'Send a command that does nothing to kitty and report the time taken to get a response.'
+        ' Exits with a non-zero exit code if no response is received within the specified timeout.'
+        ' Useful for checking if a particular kitty instance is responsive, in combination with :option:`kitty @ --to`.'
+    )
+    options_spec = '''\
+--timeout
+type=float
+default=10
+The maximum amount of time (in seconds) to wait for a response.
+'''
+    argspec = ''
+
+    def message_to_kitty(self, global_opts: RCOptions, opts: 'CLIOptions', args: ArgsType) -> PayloadType:
+        global_opts.response_timeout = max(0.001, opts.timeout)
+        return None
+
+    def response_from_kitty(self, boss: Boss, window: Optional[Window], payload_get: PayloadGetType) -> ResponseType:
+        return None
+
+    def response_for_cli(self, data: Any, response_time: float) -> Any:
+        return 'Response received in {:.2f} ms'.format(response_time * 1000)
+
+
+ping = Ping()
@@ -2,7 +2,7 @@
 # vim:fileencoding=utf-8
 # License: GPLv3 Copyright: 2020, Kovid Goyal <kovid at kovidgoyal.net>
 
-from typing import TYPE_CHECKING, Optional, Union
+from typing import TYPE_CHECKING, Any, List, Optional
 
 from .base import (
     MATCH_WINDOW_OPTION, ArgsType, Boss, PayloadGetType,
@@ -19,12 +19,17 @@ class ResizeWindow(RemoteCommand):
     self: Boolean indicating whether to close the window the command is run in
     increment: Integer specifying the resize increment
     axis: One of :code:`horizontal, vertical` or :code:`reset`
+    cols: Integer specifying the number of columns to resize the window to
+    rows: Integer specifying the number of rows to resize the window to
     '''
 
     short_desc = 'Resize the specified window'
     desc = (
         'Resize the specified window in the current layout.'
         ' Note that not all layouts can resize all windows in all directions.'
+        ' The resulting size of the window is printed as :italic:`COLUMNSxROWS`.'
+        ' If the layout prevents the window from being resized as requested,'
+        ' an error including the resulting size is reported.'
     )
     options_spec = MATCH_WINDOW_OPTION + '''\n
 --increment -i
@@ -43,25 +48,71 @@ class ResizeWindow(RemoteCommand):
 The special value :italic:`reset` will reset the layout to its default configuration.
 
 
+--cols
+type=int
+default=0
+Resize the window to have the specified number of columns, rather than
+changing its size by :option:`kitty @ resize-window --increment`.
+
+
+--rows
+type=int
+default=0
+Resize the window to have the specified number of rows, rather than
+changing its size by :option:`kitty @ resize-window --increment`.
+
+
 --self
 type=bool-set
 If specified resize the window this command is run in, rather than the active window.
 '''
     argspec = ''
-    string_return_is_error = True
 
     def message_to_kitty(self, global_opts: RCOptions, opts: 'CLIOptions', args: ArgsType) -> PayloadType:
-        return {'match': opts.match, 'increment': opts.increment, 'axis': opts.axis, 'self': opts.self}
+        if opts.cols < 0 or opts.rows < 0:
+            self.fatal('The number of columns and rows must not be negative')
+        return {
+            'match': opts.match, 'increment': opts.increment, 'axis': opts.axis, 'self': opts.self,
+            'cols': opts.cols, 'rows': opts.rows
+        }
 
     def response_from_kitty(self, boss: Boss, window: Optional[Window], payload_get: PayloadGetType) -> ResponseType:
         windows = self.windows_for_match_payload(boss, window, payload_get)
-        resized: Union[bool, None, str] = False
-        if windows and windows[0]:
-            resized = boss.resize_layout_window(
-                windows[0], increment=payload_get('increment'), is_horizontal=payload_get('axis') == 'horizontal',
+        if not windows or not windows[0]:
+            return False
+        window = windows[0]
+        errors: List[str] = []
+        cols, rows = payload_get('cols'), payload_get('rows')
+        if cols or rows:
+            for target, is_horizontal in ((cols, True), (rows, False)):
+                # sizes in the layout are not exactly proportional to cells,
+                # so try a few times to reach the target
+                for i in range(4):
+                    current = window.geometry.xnum if is_horizontal else window.geometry.ynum
+                    if not target or target == current or boss.resize_layout_window(
+                            window, increment=target - current, is_horizontal=is_horizontal) is not None:
+                        break
+            g = window.geometry
+            if cols and g.xnum != cols:
+                errors.append(f'Could not resize to {cols} columns')
+            if rows and g.ynum != rows:
+                errors.append(f'Could not resize to {rows} rows')
+        else:
+            err = boss.resize_layout_window(
+                window, increment=payload_get('increment'), is_horizontal=payload_get('axis') == 'horizontal',
                 reset=payload_get('axis') == 'reset'
             )
-        return resized
+            if isinstance(err, str):
+                errors.append(err)
+        return {'cols': window.geometry.xnum, 'rows': window.geometry.ynum, 'error': ', '.join(errors)}
+
+    def response_for_cli(self, data: Any, response_time: float) -> Any:
+        if isinstance(data, dict):
+            size = '{}x{}'.format(data['cols'], data['rows'])
+            if data['error']:
+                raise SystemExit('{}, the window is now {}'.format(data['error'], size))
+            return size
+        return data
 
 
 resize_window = ResizeWindow()
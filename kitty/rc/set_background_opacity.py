@@ -3,7 +3,11 @@
 # License: GPLv3 Copyright: 2020, Kovid Goyal <kovid at kovidgoyal.net>
 
 
-from typing import TYPE_CHECKING, Optional
+from typing import TYPE_CHECKING, List, Optional
+
+from kitty.fast_data_types import (
+    background_opacity_of, os_window_is_semi_transparent
+)
 
 from .base import (
     MATCH_TAB_OPTION, MATCH_WINDOW_OPTION, ArgsType, Boss, OpacityError,
@@ -18,7 +22,7 @@
 class SetBackgroundOpacity(RemoteCommand):
 
     '''
-    opacity+: A number between 0.1 and 1
+    opacity+: A number between 0.1 and 1 or the string :code:`reset`
     match_window: Window to change opacity in
     match_tab: Tab to change opacity in
     all: Boolean indicating operate on all windows
@@ -29,6 +33,8 @@ class SetBackgroundOpacity(RemoteCommand):
         'Set the background opacity for the specified windows. This will only work if you have turned on'
         ' :opt:`dynamic_background_opacity` in :file:`kitty.conf`. The background opacity affects all kitty windows in a'
         ' single os_window. For example: kitty @ set-background-opacity 0.5'
+        ' Use :italic:`reset` to restore the opacity to the value of :opt:`background_opacity`.'
+        ' The resulting background opacity of the affected OS windows is printed.'
     )
     options_spec = '''\
 --all -a
@@ -41,7 +47,16 @@ class SetBackgroundOpacity(RemoteCommand):
     args_count = 1
 
     def message_to_kitty(self, global_opts: RCOptions, opts: 'CLIOptions', args: ArgsType) -> PayloadType:
-        opacity = max(0.1, min(float(args[0]), 1.0))
+        if args[0] == 'reset':
+            opacity = args[0]
+        else:
+            try:
+                val = float(args[0])
+            except ValueError:
+                val = -1
+            if not 0.1 <= val <= 1:
+                self.fatal(f'The opacity must be a number between 0.1 and 1, not: {args[0]}')
+            opacity = val
         return {
                 'opacity': opacity, 'match_window': opts.match,
                 'all': opts.all, 'match_tab': opts.match_tab
@@ -51,8 +66,17 @@ def response_from_kitty(self, boss: Boss, window: Optional[Window], payload_get:
         if not boss.opts.dynamic_background_opacity:
             raise OpacityError('You must turn on the dynamic_background_opacity option in kitty.conf to be able to set background opacity')
         windows = self.windows_for_payload(boss, window, payload_get)
-        for os_window_id in {w.os_window_id for w in windows}:
-            boss._set_os_window_background_opacity(os_window_id, payload_get('opacity'))
+        opacity = payload_get('opacity')
+        if opacity == 'reset':
+            opacity = boss.opts.background_opacity
+        os_window_ids = tuple(dict.fromkeys(w.os_window_id for w in windows))
+        if not all(map(os_window_is_semi_transparent, os_window_ids)):
+            raise OpacityError('Transparency is not supported by the window system, this usually means your desktop environment does not support compositing')
+        ans: List[str] = []
+        for os_window_id in os_window_ids:
+            boss._set_os_window_background_opacity(os_window_id, opacity)
+            ans.append('{:g}'.format(background_opacity_of(os_window_id) or opacity))
+        return '\n'.join(ans)
 
 
 set_background_opacity = SetBackgroundOpacity()
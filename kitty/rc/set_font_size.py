@@ -4,9 +4,11 @@
 
 from typing import TYPE_CHECKING, Optional
 
+from kitty.fast_data_types import os_window_font_size
+
 from .base import (
-    ArgsType, Boss, PayloadGetType, PayloadType, RCOptions, RemoteCommand,
-    ResponseType, Window
+    MATCH_WINDOW_OPTION, ArgsType, Boss, MatchError, PayloadGetType,
+    PayloadType, RCOptions, RemoteCommand, ResponseType, Window
 )
 
 if TYPE_CHECKING:
@@ -15,40 +17,69 @@
 
 class SetFontSize(RemoteCommand):
     '''
-    size+: The new font size in pts (a positive number)
+    size: The new font size in pts (a positive number), if absent the font size is only queried
     all: Boolean whether to change font size in the current window or all windows
     increment_op: The string ``+`` or ``-`` to interpret size as an increment
+    match: Which windows to change the font size in
     '''
 
     short_desc = 'Set the font size in the active top-level OS window'
     desc = (
         'Sets the font size to the specified size, in pts. Note'
         ' that in kitty all sub-windows in the same OS window'
-        ' must have the same font size. A value of zero'
+        ' must have the same font size. A value of zero or :italic:`reset`'
         ' resets the font size to default. Prefixing the value'
         ' with a + or - increments the font size by the specified'
-        ' amount.'
+        ' amount. If no size is specified, the font size is not changed.'
+        ' When using :option:`kitty @ set-font-size --match` the font size is changed'
+        ' in the OS windows containing the matched windows.'
+        ' The resulting font size of each affected OS window is printed, one per line.'
     )
-    argspec = 'FONT_SIZE'
-    args_count = 1
+    argspec = '[FONT_SIZE]'
     options_spec = '''\
 --all -a
 type=bool-set
 By default, the font size is only changed in the active OS window,
 this option will cause it to be changed in all OS windows.
-'''
+
+
+''' + MATCH_WINDOW_OPTION
 
     def message_to_kitty(self, global_opts: RCOptions, opts: 'CLIOptions', args: ArgsType) -> PayloadType:
-        if not args:
-            self.fatal('No font size specified')
-        fs = args[0]
-        inc = fs[0] if fs and fs[0] in '+-' else None
-        return {'size': abs(float(fs)), 'all': opts.all, 'increment_op': inc}
+        if len(args) > 1:
+            self.fatal('Must specify at most one font size')
+        ans = {'all': opts.all, 'match': opts.match}
+        if args:
+            fs = args[0]
+            if fs == 'reset':
+                fs = '0'
+            inc = fs[0] if fs and fs[0] in '+-' else None
+            try:
+                ans['size'] = abs(float(fs))
+            except ValueError:
+                self.fatal(f'Invalid font size: {fs}')
+            ans['increment_op'] = inc
+        return ans
 
     def response_from_kitty(self, boss: Boss, window: Optional[Window], payload_get: PayloadGetType) -> ResponseType:
-        boss.change_font_size(
-            payload_get('all'),
-            payload_get('increment_op'), payload_get('size'))
+        os_window_ids = None
+        match = payload_get('match')
+        if match:
+            windows = tuple(boss.match_windows(match))
+            if not windows:
+                raise MatchError(match)
+            os_window_ids = list(dict.fromkeys(w.os_window_id for w in windows))
+        size = payload_get('size')
+        if size is None:
+            if payload_get('all'):
+                os_window_ids = list(boss.os_window_map)
+            elif os_window_ids is None:
+                w = boss.active_window
+                os_window_ids = [] if w is None else [w.os_window_id]
+            sizes = {wid: os_window_font_size(wid) for wid in os_window_ids}
+        else:
+            sizes = boss.change_font_size(payload_get('all'), payload_get('increment_op'), size, os_window_ids)
+        return '\n'.join(f'{sz:g}' for sz in sizes.values() if sz)
 
 
 set_font_size = SetFontSize()
@@ -0,0 +1,62 @@
+#!/usr/bin/env python
+# vim:fileencoding=utf-8
+# License: GPLv3 Copyright: 2021, Kovid Goyal <kovid at kovidgoyal.net>
+
+from typing import TYPE_CHECKING, Optional
+
+from .base import (
+    MATCH_WINDOW_OPTION, ArgsType, Boss, MatchError, PayloadGetType,
+    PayloadType, RCOptions, RemoteCommand, ResponseType, Window
+)
+
+if TYPE_CHECKING:
+    from kitty.cli_stub import SetUserVarRCOptions as CLIOptions
+
+
+class SetUserVar(RemoteCommand):
+
+    '''
+    key+: The name of the variable
+    value: The value of the variable, if absent the variable is removed
+    match: Which windows to set the variable in
+    '''
+
+    short_desc = 'Set a user variable on windows'
+    desc = (
+        'Set a variable on the specified window(s). User variables are arbitrary key/value pairs'
+        ' that kitty stores with the window, for your own scripts to use. They can be read back with'
+        ' :ref:`at_get-user-var` and used to match windows with :italic:`user:KEY=VALUE`.'
+        ' If you do not specify a value, the variable is removed. If you use the'
+        ' :option:`kitty @ set-user-var --match` option the variable will be set for all matched windows.'
+        ' By default, only the window in which the command is run is affected.'
+    )
+    options_spec = MATCH_WINDOW_OPTION
+    argspec = 'KEY [VALUE]'
+
+    def message_to_kitty(self, global_opts: RCOptions, opts: 'CLIOptions', args: ArgsType) -> PayloadType:
+        if not args:
+            self.fatal('Must specify the name of the variable')
+        if len(args) > 2:
+            self.fatal('Too many arguments, quote the value if it contains spaces')
+        ans = {'match': opts.match, 'key': args[0]}
+        if len(args) > 1:
+            ans['value'] = args[1]
+        return ans
+
+    def response_from_kitty(self, boss: Boss, window: Optional[Window], payload_get: PayloadGetType) -> ResponseType:
+        windows = [window or boss.active_window]
+        match = payload_get('match')
+        if match:
+            windows = list(boss.match_windows(match))
+            if not windows:
+                raise MatchError(match)
+        key, value = payload_get('key'), payload_get('value')
+        for window in windows:
+            if window:
+                if value is None:
+                    window.user_vars.pop(key, None)
+                else:
+                    window.user_vars[key] = value
+
+
+set_user_var = SetUserVar()
@@ -2,7 +2,7 @@
 # vim:fileencoding=utf-8
 # License: GPLv3 Copyright: 2020, Kovid Goyal <kovid at kovidgoyal.net>
 
-from typing import TYPE_CHECKING, Optional
+from typing import TYPE_CHECKING, List, Optional
 
 from .base import (
     MATCH_WINDOW_OPTION, ArgsType, Boss, MatchError, PayloadGetType,
@@ -17,24 +17,40 @@ class SignalChild(RemoteCommand):
 
     '''
     signals: The signals, a list of names, such as SIGTERM, SIGKILL, SIGUSR1, etc.
-    match: Which windows to change the title in
+    match: Which windows to send the signals to
     '''
 
     short_desc = 'Send a signal to the foreground process in the specified window'
     desc = (
         'Send one or more signals to the foreground process in the specified window(s).'
         ' If you use the :option:`kitty @ signal-child --match` option'
-        ' the title will be set for all matched windows. By default, only the active'
-        ' window is affected. If you do not specify any signals, :code:`SIGINT` is sent by default.'
+        ' the signals will be sent to all matched windows. By default, only the active'
+        ' window is affected. Signals can be specified by name, with or without the :code:`SIG` prefix,'
+        ' or by number. If you do not specify any signals, :code:`SIGINT` is sent by default.'
         ' You can also map this to a keystroke in kitty.conf, for example::\n\n'
         '    map F1 signal_child SIGTERM'
     )
     options_spec = '''\
     ''' + '\n\n' + MATCH_WINDOW_OPTION
     argspec = '[SIGNAL_NAME ...]'
+    string_return_is_error = True
 
     def message_to_kitty(self, global_opts: RCOptions, opts: 'CLIOptions', args: ArgsType) -> PayloadType:
-        return {'match': opts.match, 'signals': [x.upper() for x in args] or ['SIGINT']}
+        import signal
+        signals: List[str] = []
+        for x in args:
+            try:
+                if x.isdigit():
+                    name = signal.Signals(int(x)).name
+                else:
+                    name = x.upper()
+                    if not name.startswith('SIG'):
+                        name = 'SIG' + name
+                    signal.Signals[name]
+            except (ValueError, KeyError):
+                self.fatal('{} is not a valid signal'.format(x))
+            signals.append(name)
+        return {'match': opts.match, 'signals': signals or ['SIGINT']}
 
     def response_from_kitty(self, boss: Boss, window: Optional[Window], payload_get: PayloadGetType) -> ResponseType:
         import signal
@@ -44,10 +60,20 @@ def response_from_kitty(self, boss: Boss, window: Optional[Window], payload_get:
             windows = list(boss.match_windows(match))
             if not windows:
                 raise MatchError(match)
-        signals = tuple(getattr(signal, x) for x in payload_get('signals'))
+        try:
+            signals = tuple(signal.Signals[x] for x in payload_get('signals'))
+        except KeyError as err:
+            raise ValueError('{} is not a valid signal'.format(err.args[0]))
+        failures = []
         for window in windows:
             if window:
-                window.signal_child(*signals)
+                try:
+                    window.signal_child(*signals)
+                except OSError as err:
+                    failures.append('Failed to send signal to window {} with error: {}'.format(window.id, err))
+        if failures:
+            return '\n'.join(failures)
+        return None
 
 
 signal_child = SignalChild()
@@ -5,10 +5,12 @@
 import json
 import os
 import re
+import socket
 import sys
 import types
 from contextlib import suppress
 from functools import partial
+from time import monotonic
 from typing import (
     Any, Dict, Generator, Iterable, List, Optional, Tuple,
     Union, cast
@@ -33,7 +35,9 @@ def handle_cmd(boss: BossType, window: Optional[WindowType], serialized_cmd: str
     if tuple(v)[:2] > version[:2]:
         if no_response:
             return None
-        return {'ok': False, 'error': 'The kitty client you are using to send remote commands is newer than this kitty instance. This is not supported.'}
+        return {
+            'ok': False, 'error': 'The kitty client you are using to send remote commands is newer than this kitty instance. This is not supported.',
+            'error_code': 'version_mismatch'}
     c = command_for_name(cmd['cmd'])
     payload = cmd.get('payload') or {}
 
@@ -45,6 +49,10 @@ def handle_cmd(boss: BossType, window: Optional[WindowType], serialized_cmd: str
         raise
     if ans is no_response_sentinel:
         return None
+    if c.string_return_is_error and isinstance(ans, str):
+        if no_response:
+            return None
+        return {'ok': False, 'error': ans, 'error_code': 'command_failed'}
     response: Dict[str, Any] = {'ok': True}
     if ans is not None:
         response['data'] = ans
@@ -59,6 +67,20 @@ def handle_cmd(boss: BossType, window: Optional[WindowType], serialized_cmd: str
 given to the kitty instance via the :option:`kitty --listen-on` option. If not specified,
 messages are sent to the controlling terminal for this process, i.e. they
 will only work if this process is run within an existing kitty window.
+The special value :code:`auto` looks for UNIX sockets with :code:`kitty` in
+their names that are listening for connections and uses the one found. If
+more than one is found, they are listed so you can choose one.
+
+
+--json-response
+type=bool-set
+Print the response from kitty as a JSON object rather than as text. The object
+has the keys: :code:`ok`, which is true if the command succeeded, :code:`data`
+with the output of the command, if any, and in case of failure, :code:`error`
+with a description of the error and :code:`error_code` with a stable error code
+suitable for use in scripts, such as :code:`no_match`, :code:`permission_denied`,
+:code:`invalid_argument`, :code:`command_failed` or :code:`timeout`. Note that the error code may be
+absent when talking to older versions of kitty.
 '''.format, appname=appname)
 
 
@@ -116,7 +138,7 @@ def simple_recv(self, timeout: float) -> bytes:
         return b''.join(ans)
 
 
-def do_io(to: Optional[str], send: Dict, no_response: bool) -> Dict[str, Any]:
+def do_io(to: Optional[str], send: Dict, no_response: bool, response_timeout: float = 10) -> Dict[str, Any]:
     payload = send.get('payload')
     if not isinstance(payload, types.GeneratorType):
         send_data: Union[bytes, Iterable[bytes]] = encode_send(send)
@@ -133,7 +155,7 @@ def send_generator() -> Generator[bytes, None, None]:
         io.send(send_data)
         if no_response:
             return {'ok': True}
-        received = io.simple_recv(timeout=10)
+        received = io.simple_recv(timeout=response_timeout)
 
     return cast(Dict[str, Any], json.loads(received.decode('ascii')))
 
@@ -155,6 +177,36 @@ def parse_rc_args(args: List[str]) -> Tuple[RCOptions, List[str]]:
     return parse_args(args[1:], global_options_spec, 'command ...', msg, '{} @'.format(appname), result_class=RCOptions)
 
 
+def discover_listen_on() -> str:
+    import stat
+    import tempfile
+    candidates = set()
+    try:
+        with open('/proc/net/unix') as f:
+            lines = f.read().splitlines()[1:]
+    except OSError:
+        # not Linux, look for sockets in the temp directory
+        tdir = tempfile.gettempdir()
+        for name in os.listdir(tdir):
+            path = os.path.join(tdir, name)
+            with suppress(OSError):
+                if 'kitty' in name.lower() and stat.S_ISSOCK(os.stat(path).st_mode):
+                    candidates.add('unix:' + path)
+    else:
+        for line in lines:
+            parts = line.split()
+            # only listening stream sockets
+            if len(parts) > 7 and parts[4] == '0001' and int(parts[3], 16) & 0x10000:
+                path = parts[7]
+                if 'kitty' in os.path.basename(path).lower():
+                    candidates.add('unix:' + path)
+    if not candidates:
+        raise SystemExit('Could not find any kitty instances listening for remote control connections')
+    if len(candidates) > 1:
+        raise SystemExit('Found more than one kitty instance, use --to with one of:\n' + '\n'.join(sorted(candidates)))
+    return candidates.pop()
+
+
 def create_basic_command(name: str, payload: Any = None, no_response: bool = False) -> Dict[str, Any]:
     ans = {'cmd': name, 'version': version, 'no_response': no_response}
     if payload is not None:
@@ -162,9 +214,17 @@ def create_basic_command(name: str, payload: Any = None, no_response: bool = Fal
     return ans
 
 
+def print_json_response(response: Dict[str, Any]) -> None:
+    ans = {k: response[k] for k in ('ok', 'data', 'error', 'error_code') if k in response}
+    print(json.dumps(ans, indent=2, sort_keys=True))
+
+
 def main(args: List[str]) -> None:
     global_opts, items = parse_rc_args(args)
     global_opts.no_command_response = None
+    global_opts.response_timeout = 10
+    if global_opts.to == 'auto':
+        global_opts.to = discover_listen_on()
 
     if not items:
         from kitty.shell import main as smain
@@ -185,14 +245,24 @@ def main(args: List[str]) -> None:
     send = create_basic_command(cmd, payload=payload, no_response=no_response)
     if not global_opts.to and 'KITTY_LISTEN_ON' in os.environ:
         global_opts.to = os.environ['KITTY_LISTEN_ON']
-    response = do_io(global_opts.to, send, no_response)
+    start = monotonic()
+    try:
+        response = do_io(global_opts.to, send, no_response, global_opts.response_timeout)
+    except (TimeoutError, socket.timeout):
+        if global_opts.json_response:
+            print_json_response({'ok': False, 'error': 'Timed out waiting for a response from kitty', 'error_code': 'timeout'})
+        raise SystemExit('Timed out waiting for a response from kitty')
+    response_time = monotonic() - start
     if no_response:
         return
+    if global_opts.json_response:
+        print_json_response(response)
+        raise SystemExit(0 if response.get('ok') else 1)
     if not response.get('ok'):
         if response.get('tb'):
             print(response['tb'], file=sys.stderr)
         raise SystemExit(response['error'])
-    data = response.get('data')
+    data = c.response_for_cli(response.get('data'), response_time)
     if data is not None:
         if c.string_return_is_error and isinstance(data, str):
             raise SystemExit(data)
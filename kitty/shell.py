@@ -5,10 +5,12 @@
 import os
 import readline
 import shlex
+import socket
 import sys
 import traceback
 from contextlib import suppress
 from functools import lru_cache
+from time import monotonic
 from typing import Any, Dict, Generator, Iterable, List, Optional, Tuple
 
 from .cli import (
@@ -136,6 +138,7 @@ def print_help(which: Optional[str] = None) -> None:
 
 def run_cmd(global_opts: RCOptions, cmd: str, func: RemoteCommand, opts: Any, items: List[str]) -> None:
     from .remote_control import do_io
+    global_opts.response_timeout = 10
     payload = func.message_to_kitty(global_opts, opts, items)
     send = {
         'cmd': cmd,
@@ -144,14 +147,21 @@ def run_cmd(global_opts: RCOptions, cmd: str, func: RemoteCommand, opts: Any, it
     }
     if payload is not None:
         send['payload'] = payload
-    response = do_io(global_opts.to, send, func.no_response)
+    start = monotonic()
+    try:
+        response = do_io(global_opts.to, send, func.no_response, global_opts.response_timeout)
+    except (TimeoutError, socket.timeout):
+        print_err('Timed out waiting for a response from kitty')
+        return
+    response_time = monotonic() - start
     if not response.get('ok'):
         if response.get('tb'):
             print_err(response['tb'])
         print_err(response['error'])
         return
-    if 'data' in response:
-        print(response['data'])
+    data = func.response_for_cli(response.get('data'), response_time)
+    if data is not None:
+        print(data)
 
 
 def real_main(global_opts: RCOptions) -> None:
@@ -955,6 +955,15 @@ PYWRAP1(background_opacity_of) {
     Py_RETURN_NONE;
 }
 
+PYWRAP1(os_window_is_semi_transparent) {
+    id_type os_window_id = PyLong_AsUnsignedLongLong(args);
+    WITH_OS_WINDOW(os_window_id)
+        if (os_window->is_semi_transparent) { Py_RETURN_TRUE; }
+        Py_RETURN_FALSE;
+    END_WITH_OS_WINDOW
+    Py_RETURN_FALSE;
+}
+
 PYWRAP1(set_window_padding) {
     id_type os_window_id, tab_id, window_id;
     unsigned int left, top, right, bottom;
@@ -1206,6 +1215,7 @@ static PyMethodDef module_methods[] = {
     MW(mark_tab_bar_dirty, METH_O),
     MW(change_background_opacity, METH_VARARGS),
     MW(background_opacity_of, METH_O),
+    MW(os_window_is_semi_transparent, METH_O),
     MW(update_window_visibility, METH_VARARGS),
     MW(sync_os_window_title, METH_VARARGS),
     MW(global_font_size, METH_VARARGS),
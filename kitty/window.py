@@ -5,6 +5,7 @@
 import json
 import os
 import sys
+import time
 import weakref
 from collections import deque
 from enum import IntEnum
@@ -59,6 +60,7 @@ class WindowDict(TypedDict):
     is_self: bool
     lines: int
     columns: int
+    user_vars: Dict[str, str]
 
 
 class PipeData(TypedDict):
@@ -300,6 +302,7 @@ def __init__(
         self.action_on_close: Optional[Callable] = None
         self.action_on_removal: Optional[Callable] = None
         self.current_marker_spec: Optional[Tuple[str, Union[str, Tuple[Tuple[int, str], ...]]]] = None
+        self.current_marker: Optional[Callable] = None
         self.pty_resized_once = False
         self.last_reported_pty_size = (-1, -1, -1, -1)
         self.needs_attention = False
@@ -307,6 +310,8 @@ def __init__(
         self.default_title = os.path.basename(child.argv[0] or appname)
         self.child_title = self.default_title
         self.title_stack: Deque[str] = deque(maxlen=10)
+        self.user_vars: Dict[str, str] = {}
+        self.last_focused_at = 0.
         self.allow_remote_control = child.allow_remote_control
         self.id: int = add_window(tab.os_window_id, tab.id, self.title)
         self.margin = EdgeWidths()
@@ -396,6 +401,7 @@ def as_dict(self, is_focused: bool = False, is_self: bool = False) -> WindowDict
             is_self=is_self,
             lines=self.screen.lines,
             columns=self.screen.columns,
+            user_vars=self.user_vars.copy(),
         )
 
     def serialize_state(self) -> Dict[str, Any]:
@@ -406,6 +412,7 @@ def serialize_state(self) -> Dict[str, Any]:
             'override_title': self.override_title,
             'default_title': self.default_title,
             'title_stack': list(self.title_stack),
+            'user_vars': self.user_vars.copy(),
             'allow_remote_control': self.allow_remote_control,
             'cwd': self.child.current_cwd or self.child.cwd,
             'env': self.child.environ,
@@ -421,10 +428,10 @@ def current_colors(self) -> Dict:
     def matches(self, field: str, pat: MatchPatternType) -> bool:
         if not pat:
             return False
-        if field == 'env':
+        if field in ('env', 'user'):
             assert isinstance(pat, tuple)
             key_pat, val_pat = pat
-            for key, val in self.child.environ.items():
+            for key, val in (self.child.environ if field == 'env' else self.user_vars).items():
                 if key_pat.search(key) is not None and (
                         val_pat is None or val_pat.search(val) is not None):
                     return True
@@ -572,14 +579,14 @@ def hyperlink_open_confirmed(self, url: str, cwd: Optional[str], data: Dict[str,
             set_clipboard_string(url)
 
     def handle_remote_file(self, netloc: str, remote_path: str) -> None:
-        from kittens.ssh.main import get_connection_data
+        from kittens.ssh.main import get_connection_data, parse_destination
         args = self.child.foreground_cmdline
         conn_data = get_connection_data(args)
         if conn_data is None:
             get_boss().show_error('Could not handle remote file', 'No SSH connection data found in: {args}')
             return
         get_boss().run_kitten(
-            'remote_file', '--hostname', netloc.partition(':')[0], '--path', remote_path,
+            'remote_file', '--hostname', parse_destination(netloc, is_url=True)[1], '--path', remote_path,
             '--ssh-connection-data', json.dumps(conn_data)
         )
 
@@ -589,6 +596,7 @@ def focus_changed(self, focused: bool) -> None:
         call_watchers(weakref.ref(self), 'on_focus_change', {'focused': focused})
         self.screen.focus_changed(focused)
         if focused:
+            self.last_focused_at = time.time()
             changed = self.needs_attention
             self.needs_attention = False
             if changed:
@@ -873,21 +881,24 @@ def paste_bytes(self, text: Union[str, bytes]) -> None:
             text = text.encode('utf-8')
         self.screen.paste_bytes(text)
 
+    def sanitize_for_paste(self, text: bytes, bracketed: bool) -> bytes:
+        if bracketed:
+            while True:
+                new_text = text.replace(b'\033[201~', b'').replace(b'\x9b201~', b'')
+                if len(text) == len(new_text):
+                    break
+                text = new_text
+        else:
+            # Workaround for broken editors like nano that cannot handle
+            # newlines in pasted text see https://github.com/kovidgoyal/kitty/issues/994
+            text = text.replace(b'\r\n', b'\n').replace(b'\n', b'\r')
+        return text
+
     def paste(self, text: Union[str, bytes]) -> None:
         if text and not self.destroyed:
             if isinstance(text, str):
                 text = text.encode('utf-8')
-            if self.screen.in_bracketed_paste_mode:
-                while True:
-                    new_text = text.replace(b'\033[201~', b'').replace(b'\x9b201~', b'')
-                    if len(text) == len(new_text):
-                        break
-                    text = new_text
-            else:
-                # Workaround for broken editors like nano that cannot handle
-                # newlines in pasted text see https://github.com/kovidgoyal/kitty/issues/994
-                text = text.replace(b'\r\n', b'\n').replace(b'\n', b'\r')
-            self.screen.paste(text)
+            self.screen.paste(self.sanitize_for_paste(text, self.screen.in_bracketed_paste_mode))
 
     def copy_to_clipboard(self) -> None:
         text = self.text_for_selection()
@@ -963,13 +974,21 @@ def set_marker(self, spec: Union[str, Sequence[str]]) -> None:
         else:
             ftype, spec_, flags = parse_marker_spec(spec[0], spec[1:])
         key = ftype, spec_
-        self.screen.set_marker(marker_from_spec(ftype, spec_, flags))
+        self.current_marker = marker_from_spec(ftype, spec_, flags)
+        self.screen.set_marker(self.current_marker)
         self.current_marker_spec = key
 
+    def count_marks(self) -> int:
+        from .marks import count_marks
+        if self.current_marker is None:
+            return 0
+        return count_marks(self.current_marker, self.as_text(add_history=True).splitlines())
+
     def remove_marker(self) -> None:
         if self.current_marker_spec is not None:
             self.screen.set_marker()
             self.current_marker_spec = None
+            self.current_marker = None
 
     def scroll_to_mark(self, prev: bool = True, mark: int = 0) -> None:
         self.screen.scroll_to_next_mark(mark, prev)
@@ -0,0 +1,79 @@
+#!/usr/bin/env python3
+# vim:fileencoding=utf-8
+# License: GPL v3 Copyright: 2021, Kovid Goyal <kovid at kovidgoyal.net>
+
+
+from . import BaseTest
+
+
+class TestClipboard(BaseTest):
+
+    def test_clipboard_size_limits(self):
+        import io
+        from contextlib import redirect_stderr
+        from kittens.clipboard.main import limited, num_of_chunks
+        from kittens.tui.operations import (
+            CLIPBOARD_CHUNK_SIZE, write_to_clipboard
+        )
+
+        self.ae(num_of_chunks(None), 0)
+        self.ae(num_of_chunks(b'x'), 1)
+        self.ae(num_of_chunks(b'x' * CLIPBOARD_CHUNK_SIZE), 1)
+        self.ae(num_of_chunks(b'x' * (CLIPBOARD_CHUNK_SIZE + 1)), 2)
+        data = b'x' * (2 * CLIPBOARD_CHUNK_SIZE + 1)
+        # one escape code to clear the clipboard and one per chunk
+        self.ae(write_to_clipboard(data).count('\x07'), num_of_chunks(data) + 1)
+
+        data = 'aé'.encode('utf-8') * 4
+        self.ae(limited(data, 0), data)
+        self.ae(limited(data, len(data)), data)
+        with self.assertRaises(SystemExit):
+            limited(data, -1)
+        with self.assertRaises(SystemExit):
+            limited(data, 4)
+        with self.assertRaises(SystemExit):
+            limited(data, 4, 'error')
+        err = io.StringIO()
+        with redirect_stderr(err):
+            # partial UTF-8 sequences are not sent
+            self.ae(limited(data, 5, 'truncate'), 'aéa'.encode('utf-8'))
+            self.ae(limited(b'\xff' * 8, 5, 'truncate'), b'\xff' * 5)
+        self.assertIn('truncating to 4 bytes', err.getvalue())
+
+    def test_clipboard_append_size_limit(self):
+        from kittens.clipboard.main import Clipboard
+
+        class Args:
+            use_primary = False
+            passthrough = 'none'
+            append = True
+            append_separator = '-'
+            get_clipboard = False
+            wait_for_completion = False
+            max_size = 6
+            on_oversize = 'error'
+
+        def handler(on_oversize):
+            args = Args()
+            args.on_oversize = on_oversize
+            h = Clipboard(b'new', args)
+            h.written, h.printed, h.return_codes = [], [], []
+            h.write_to_clipboard = h.written.append
+            h.print = lambda *a, **kw: h.printed.append(' '.join(map(str, a)))
+            h.quit_loop = h.return_codes.append
+            return h
+
+        h = handler('error')
+        h.on_clipboard_response('')
+        self.ae(h.written, [b'new'])
+        # appending to the current contents would exceed --max-size
+        h = handler('error')
+        h.on_clipboard_response('old')
+        self.ae(h.written, [])
+        self.ae(h.return_codes, [1])
+        self.assertIn('larger than the limit of 6 bytes', h.error_message)
+        h = handler('truncate')
+        h.on_clipboard_response('old')
+        self.ae(h.written, [b'old-ne'])
+        self.ae(h.return_codes, [0])
+        self.assertIn('truncating to 6 bytes', h.printed[0])
@@ -44,3 +44,116 @@ def h(s, e, w):
 
         highlights = [h(0, 1, 1), h(1, 3, 2)]
         self.ae(['S1SaE1ES2SbcE2Ed'], split_with_highlights('abcd', 10, highlights))
+
+    def test_whitespace_args(self):
+        from kittens.diff.patch import whitespace_args
+        self.ae([], whitespace_args(['git', 'diff'], 'none'))
+        self.ae(['--ignore-space-at-eol'], whitespace_args(['git', 'diff'], 'trailing'))
+        self.ae(['--ignore-all-space'], whitespace_args(['/usr/bin/diff', '-p'], 'all'))
+        self.ae([], whitespace_args(['colordiff'], 'all'))
+
+    def test_git_file(self):
+        import os
+        import shutil
+        import subprocess
+        import tempfile
+        from kittens.diff.main import get_git_file
+        if not shutil.which('git'):
+            self.skipTest('git not available')
+        tdir = os.path.realpath(tempfile.mkdtemp())
+        self.addCleanup(shutil.rmtree, tdir)
+        cwd = os.getcwd()
+        self.addCleanup(os.chdir, cwd)
+        os.chdir(tdir)
+
+        def git(*args):
+            subprocess.check_call(['git', '-c', 'user.name=x', '-c', 'user.email=x@x'] + list(args), stdout=subprocess.DEVNULL)
+
+        os.mkdir('sub')
+        for name, data in (('a', 'a1'), ('sub/b', 'b1')):
+            with open(name, 'w') as f:
+                f.write(data)
+        git('init', '-q')
+        git('add', '.')
+        git('commit', '-qm', 'one')
+        with open('sub/b', 'w') as f:
+            f.write('b2')
+
+        # a directory with a single file must be returned as a directory
+        ans = get_git_file('HEAD', 'sub')
+        self.assertTrue(os.path.isdir(ans))
+        self.ae(os.listdir(ans), ['b'])
+        # absolute paths must refer to the archived file, not the working tree
+        ans = get_git_file('HEAD', os.path.join(tdir, 'sub', 'b'))
+        self.assertNotEqual(ans, os.path.join(tdir, 'sub', 'b'))
+        with open(ans) as f:
+            self.ae(f.read(), 'b1')
+        # relative paths are relative to the current directory
+        os.chdir('sub')
+        with open(get_git_file('HEAD', 'b')) as f:
+            self.ae(f.read(), 'b1')
+
+    def test_copy_selection(self):
+        import os
+        import shutil
+        import tempfile
+        from kittens.diff.main import DiffHandler
+        from kittens.diff.render import ChangeRef, Line, LineRef, Reference
+        from kitty.utils import ScreenSize
+        tdir = tempfile.mkdtemp()
+        self.addCleanup(shutil.rmtree, tdir)
+        left, right, other = (os.path.join(tdir, x) for x in 'lro')
+        for path, data in ((left, 'a\nb\nc\nd\n'), (right, 'a\nB\nc\nD\n'), (other, 'x\n')):
+            with open(path, 'w') as f:
+                f.write(data)
+
+        copied = []
+
+        class Cmd:
+            def write_to_clipboard(self, text):
+                copied.append(text)
+
+            def bell(self):
+                copied.append(None)
+
+        h = DiffHandler.__new__(DiffHandler)
+        h.cmd = Cmd()
+        h.screen_size = ScreenSize(10, 80, 0, 0, 0, 0)
+        h.scroll_pos = 0
+        h.selection = None
+        h.draw_screen = lambda: None
+        h.scroll_lines = lambda amt: None
+        c1, c2 = ChangeRef(left, 1, 1, right, 1, 1), ChangeRef(left, 3, 1, right, 3, 1)
+        c3 = ChangeRef('', 0, 0, other, 0, 1)
+        h.diff_lines = (
+            Line('', Reference(left, LineRef(0))),
+            Line('', Reference(right, LineRef(1)), True, change=c1),
+            Line('', Reference(left, LineRef(2))),
+            Line('', Reference(right, LineRef(3)), True, change=c2),
+            Line('', Reference(other, LineRef(0)), True, change=c3),
+        )
+        h.change_lines = [1, 3, 4]
+
+        h.copy_change()
+        self.ae(copied, [None])
+        del copied[:]
+        h.extend_selection()
+        self.ae(h.selection, (0, 0))
+        self.ae(h.selected_line_range(), (1, 1))
+        h.copy_change()
+        h.copy_change(old=True)
+        self.ae(copied, ['B\n', 'b\n'])
+        del copied[:]
+        h.extend_selection()
+        self.ae(h.selected_line_range(), (1, 3))
+        h.copy_change()
+        h.copy_change(old=True)
+        self.ae(copied, ['B\nc\nD\n', 'b\nc\nd\n'])
+        del copied[:]
+        # selections cannot span files
+        h.extend_selection()
+        self.ae(copied, [None])
+        self.ae(h.selection, (0, 1))
+        h.extend_selection(backwards=True)
+        h.extend_selection(backwards=True)
+        self.ae(h.selection, (0, 0))
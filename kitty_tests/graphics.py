@@ -777,3 +777,15 @@ def test_graphics_quota_enforcement(self):
         s.reset()
         self.ae(g.image_count, 0)
         self.assertEqual(g.disk_cache.total_size, 0)
+
+    def test_icat_frame_gap(self):
+        from kittens.icat.main import frame_gap
+        self.ae(frame_gap(100), 100)
+        self.ae(frame_gap(0), -1)
+        self.ae(frame_gap(100, speed=2), 50)
+        self.ae(frame_gap(100, speed=0.5), 200)
+        # sped up or overridden gaps must never become gapless frames
+        self.ae(frame_gap(10, speed=1000), 1)
+        self.ae(frame_gap(100, frame_delay=0), 1)
+        self.ae(frame_gap(0, frame_delay=30), 30)
+        self.ae(frame_gap(100, frame_delay=30, speed=2), 30)
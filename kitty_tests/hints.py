@@ -58,3 +58,80 @@ def create_marks(text, cols=60):
                 marks = create_marks(testcase)
                 ips = [m.text for m in marks]
                 self.ae(ips, expected)
+
+    def test_hints_region(self):
+        from kittens.hints.main import parse_hints_args, functions_for, mark, convert_text, marks_in_region, parse_region
+        args = parse_hints_args(['--type', 'word'])[0]
+        pattern, post_processors = functions_for(args)
+        text = convert_text('hello world foo\nabc defgh ijkl mnop\nqrst uvwx yz12', 20)
+        marks = tuple(mark(pattern, post_processors, text, args))
+        marks = marks_in_region(text, marks, parse_region('20,2,5,1'))
+        self.ae([m.text for m in marks], ['ijkl', 'mnop', 'uvwx', 'yz12'])
+        self.ae([m.index for m in marks], [0, 1, 2, 3])
+        self.assertRaises(ValueError, parse_region, '1,2,3')
+
+    def test_hints_status_line(self):
+        from kittens.hints.main import convert_text, reserve_last_line
+        text = convert_text('a\nb\nc', 5)
+        self.ae(reserve_last_line(text, 3).split('\n'), text.split('\n')[:2])
+        self.ae(reserve_last_line(text, 4), text)
+
+    def test_hints_history(self):
+        import json
+        import os
+        import shutil
+        import tempfile
+        from kittens.hints.main import add_to_history, load_history
+        from kitty.constants import cache_dir
+        tdir = tempfile.mkdtemp()
+        self.addCleanup(shutil.rmtree, tdir)
+        cache_dir.set_override(tdir)
+        self.addCleanup(cache_dir.clear_override)
+
+        self.ae(load_history('url'), [])
+        add_to_history('url', ('a', 'b'), 3)
+        with open(os.path.join(tdir, 'hints-history.json')) as f:
+            self.ae(json.load(f), {'url': ['b', 'a']})
+        # re-selected items move to the front and the oldest ones are dropped
+        add_to_history('url', ('c', 'a', 'd'), 3)
+        self.ae(load_history('url'), ['d', 'a', 'c'])
+        add_to_history('path', ('x',), 3)
+        self.ae(load_history('path'), ['x'])
+        self.ae(load_history('url'), ['d', 'a', 'c'])
+        add_to_history('url', ('e',), 1)
+        self.ae(load_history('url'), ['e'])
+
+    def test_hints_confirm(self):
+        from kittens.hints.main import Hints, Mark, parse_hints_args
+        from kitty.key_encoding import KeyEvent
+
+        def chosen(confirm, text, press_enter=False):
+            args = parse_hints_args(['--alphabet=ab', '--confirm=' + confirm])[0]
+            # the hints are b, ba and bb
+            marks = [Mark(i, 0, 1, str(i), {}) for i in (1, 2, 3)]
+            h = Hints('', marks, {m.index: m for m in marks}, args)
+            h.draw_screen = lambda: None
+            h.quit_loop = lambda code: None
+            h.on_text(text)
+            if press_enter:
+                h.on_key(KeyEvent(key='ENTER'))
+            return [m.index for m in h.chosen]
+
+        self.ae(chosen('auto', 'b'), [])
+        self.ae(chosen('auto', 'ba'), [2])
+        self.ae(chosen('auto', 'b', True), [1])
+        self.ae(chosen('always', 'ba'), [])
+        self.ae(chosen('always', 'ba', True), [2])
+        self.ae(chosen('never', 'b'), [1])
+        self.ae(chosen('never', 'bb'), [3])
+        self.assertRaises(SystemExit, parse_hints_args, ['--confirm=alwys'])
+
+    def test_hints_history_status_line(self):
+        from kittens.hints.main import history_marks
+        history = ['a', 'b', 'c']
+        text, marks = history_marks(history, 5)
+        self.ae([m.text for m in marks], history)
+        # the last row is kept free for the action status line
+        text, marks = history_marks(history, 5, 3)
+        self.ae(len(text.split('\n')), 2)
+        self.ae([m.text for m in marks], history[:2])
@@ -0,0 +1,60 @@
+#!/usr/bin/env python3
+# vim:fileencoding=utf-8
+# License: GPL v3 Copyright: 2021, Kovid Goyal <kovid at kovidgoyal.net>
+
+
+from . import BaseTest
+
+
+class TestRemoteControl(BaseTest):
+
+    def test_paste_file(self):
+        import os
+        import tempfile
+        from kitty.rc.base import parse_subcommand_cli
+        from kitty.rc.paste_file import paste_file
+        from kitty.window import Window
+
+        class Screen:
+            in_bracketed_paste_mode = True
+
+        class FakeWindow:
+            id = 1
+            destroyed = False
+            sanitize_for_paste = Window.sanitize_for_paste
+
+            def __init__(self):
+                self.screen = Screen()
+                self.output = b''
+
+            def write_to_child(self, data):
+                self.output += data
+
+        w = FakeWindow()
+
+        class Boss:
+            active_window = w
+
+        data = b'line1\r\nend\x1b[201~' * 600
+        with tempfile.NamedTemporaryFile(delete=False) as f:
+            f.write(data)
+        self.addCleanup(os.remove, f.name)
+
+        def paste(*args, bracketed_mode=True, toggle_mode_after_first=False):
+            w.output = b''
+            w.screen.in_bracketed_paste_mode = bracketed_mode
+            opts, items = parse_subcommand_cli(paste_file, ['paste-file'] + list(args) + [f.name])
+            chunks = list(map(dict, paste_file.message_to_kitty(None, opts, items)))
+            self.assertGreater(len(chunks), 2)
+            for chunk in chunks:
+                paste_file.response_from_kitty(Boss(), None, chunk.get)
+                if toggle_mode_after_first:
+                    w.screen.in_bracketed_paste_mode = not w.screen.in_bracketed_paste_mode
+            return w.output
+
+        clean = data.replace(b'\x1b[201~', b'')
+        self.ae(paste(), b'\x1b[200~' + clean + b'\x1b[201~')
+        # the mode is decided by the first chunk, the paste must be closed
+        self.ae(paste(toggle_mode_after_first=True), b'\x1b[200~' + clean + b'\x1b[201~')
+        self.ae(paste(bracketed_mode=False, toggle_mode_after_first=True), data.replace(b'\r\n', b'\r'))
+        self.ae(paste('--no-bracketed'), data)
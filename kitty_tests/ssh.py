@@ -0,0 +1,35 @@
+#!/usr/bin/env python3
+# vim:fileencoding=utf-8
+# License: GPL v3 Copyright: 2021, Kovid Goyal <kovid at kovidgoyal.net>
+
+
+from . import BaseTest
+
+
+class SSHTest(BaseTest):
+
+    def test_ssh_destination_parsing(self):
+        from kittens.ssh.main import parse_destination
+
+        def t(dest, user, hostname, port=None):
+            self.ae(parse_destination(dest), (user, hostname, port))
+
+        t('host', '', 'host')
+        t('user@host', 'user', 'host')
+        # ssh does not parse ports from bare destinations
+        t('host:2222', '', 'host:2222')
+        t('user@host:2222', 'user', 'host:2222')
+        t('ssh://user@host:2222', 'user', 'host', 2222)
+        t('ssh://host/', '', 'host')
+        t('2001:db8::1', '', '2001:db8::1')
+        t('user@[2001:db8::1]', 'user', '2001:db8::1')
+        t('[2001:db8::1]:2222', '', '2001:db8::1')
+        t('ssh://user@[2001:db8::1]:2222', 'user', '2001:db8::1', 2222)
+
+        def u(netloc, user, hostname, port=None):
+            self.ae(parse_destination(netloc, is_url=True), (user, hostname, port))
+
+        # the network location of URLs, such as the ones in hyperlinks
+        u('host:2222', '', 'host', 2222)
+        u('user@[2001:db8::1]:2222', 'user', '2001:db8::1', 2222)
+        u('[2001:db8::1]', '', '2001:db8::1')